@@ -0,0 +1,461 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Calendar: A common interface for calendar conversions.
+//
+// This package does not correspond to a chapter of the book.  Calendar
+// conversions in this library are otherwise found in package julian
+// (Gregorian and Julian calendars), package jm (Jewish, Moslem, and Persian
+// calendars) and package easter, with a variety of signatures.  Here the
+// calendars are wrapped, with some additional calendars, by the single
+// interface Calendar.
+//
+// All calendars of this package convert whole days.  A date converts to
+// the JD at 0h of the day, and a JD converts to the date of the calendar
+// day containing it.  Days are considered to begin at midnight, even for
+// calendars such as the Jewish and Moslem calendars where the day
+// traditionally begins at sunset.
+//
+// The Maya Long Count has no months and so does not satisfy the Calendar
+// interface.  It is implemented separately as type LongCount.
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/jm"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+)
+
+// Calendar is implemented by calendars that number days by year, month,
+// and day of month.
+type Calendar interface {
+	// ToJD returns the JD at 0h of the given date.
+	ToJD(y, m, d int) float64
+	// FromJD returns the date of the day containing jd.
+	FromJD(jd float64) (y, m, d int)
+	// MonthName returns the name of month m of year y.
+	MonthName(y, m int) string
+}
+
+// dayNumber returns the integer JD of the noon of the day containing jd.
+func dayNumber(jd float64) int {
+	return int(math.Floor(jd + .5))
+}
+
+// Gregorian is the Gregorian calendar, extended proleptically to dates
+// before its introduction.
+type Gregorian struct{}
+
+// ToJD implements Calendar.
+func (Gregorian) ToJD(y, m, d int) float64 {
+	return julian.CalendarGregorianToJD(y, m, float64(d))
+}
+
+// FromJD implements Calendar.
+func (Gregorian) FromJD(jd float64) (y, m, d int) {
+	y, m, df := julian.JDToCalendarGregorian(jd)
+	return y, m, int(df)
+}
+
+// MonthName implements Calendar.  Names are those of the time package.
+func (Gregorian) MonthName(y, m int) string { return time.Month(m).String() }
+
+// Julian is the Julian calendar, extended proleptically to dates after
+// the introduction of the Gregorian calendar.
+type Julian struct{}
+
+// ToJD implements Calendar.
+func (Julian) ToJD(y, m, d int) float64 {
+	return julian.CalendarJulianToJD(y, m, float64(d))
+}
+
+// FromJD implements Calendar.
+func (Julian) FromJD(jd float64) (y, m, d int) {
+	y, m, df := julian.JDToCalendarJulian(jd)
+	return y, m, int(df)
+}
+
+// MonthName implements Calendar.  Names are those of the time package.
+func (Julian) MonthName(y, m int) string { return time.Month(m).String() }
+
+// Hebrew is the Jewish calendar.
+//
+// Months are numbered in order from the beginning of the year, so that
+// Tishri = 1.  In a common year Adar = 6 and Elul = 12.  In a leap year
+// Adar I = 6, Adar II = 7, and Elul = 13.
+//
+// Computations are based on jm.JewishCalendar.
+type Hebrew struct{}
+
+// HebrewLeapYear returns true if year y of the Jewish calendar has 13 months.
+func HebrewLeapYear(y int) bool {
+	return (7*y+1)%19 < 7
+}
+
+// hebrewNewYear returns the JD of 1 Tishri of Jewish year y.
+func hebrewNewYear(y int) float64 {
+	// jm.JewishCalendar takes a Western year and returns the new year
+	// that falls in it, the start of the following Jewish year.
+	wy := y - 3761
+	_, _, _, mNY, dNY, _, _ := jm.JewishCalendar(wy)
+	if wy < 1583 {
+		return julian.CalendarJulianToJD(wy, mNY, float64(dNY))
+	}
+	return julian.CalendarGregorianToJD(wy, mNY, float64(dNY))
+}
+
+// hebrewMonthLengths returns the lengths of the months of Jewish year y.
+func hebrewMonthLengths(y int) []int {
+	days := int(hebrewNewYear(y+1) - hebrewNewYear(y))
+	ml := []int{30, 29, 30, 29, 30, 29, 30, 29, 30, 29, 30, 29}
+	if HebrewLeapYear(y) {
+		// Adar I inserted before Adar, which becomes Adar II.
+		ml = []int{30, 29, 30, 29, 30, 30, 29, 30, 29, 30, 29, 30, 29}
+	}
+	switch days % 10 {
+	case 3: // deficient year, Kislev has 29 days
+		ml[2] = 29
+	case 5: // complete year, Ḥeshvan has 30 days
+		ml[1] = 30
+	}
+	return ml
+}
+
+// ToJD implements Calendar.
+func (Hebrew) ToJD(y, m, d int) float64 {
+	jd := hebrewNewYear(y) + float64(d-1)
+	for _, l := range hebrewMonthLengths(y)[:m-1] {
+		jd += float64(l)
+	}
+	return jd
+}
+
+// FromJD implements Calendar.
+func (Hebrew) FromJD(jd float64) (y, m, d int) {
+	wy, _, _ := julian.JDToCalendar(jd)
+	y = wy + 3761
+	ny := hebrewNewYear(y)
+	if ny > jd {
+		y--
+		ny = hebrewNewYear(y)
+	}
+	n := dayNumber(jd) - dayNumber(ny)
+	m = 1
+	for _, l := range hebrewMonthLengths(y) {
+		if n < l {
+			break
+		}
+		n -= l
+		m++
+	}
+	return y, m, n + 1
+}
+
+var hmonths = [12]string{
+	"Tishri",
+	"Ḥeshvan",
+	"Kislev",
+	"Ṭevet",
+	"Shevaṭ",
+	"Adar",
+	"Nisan",
+	"Iyyar",
+	"Sivan",
+	"Tammuz",
+	"Av",
+	"Elul",
+}
+
+// MonthName implements Calendar.
+func (Hebrew) MonthName(y, m int) string {
+	if !HebrewLeapYear(y) || m < 6 {
+		return hmonths[m-1]
+	}
+	switch m {
+	case 6:
+		return "Adar I"
+	case 7:
+		return "Adar II"
+	}
+	return hmonths[m-2]
+}
+
+// Islamic is the tabular Moslem calendar.
+//
+// The leap year rule is that of jm.MoslemLeapYear.  The epoch is the
+// "civil" epoch of 622 July 16 in the Julian calendar.
+type Islamic struct{}
+
+// JD of the day before 1 Muḥarram 1 A.H.
+const islamicEpoch = 1948438.5
+
+// ToJD implements Calendar.
+func (Islamic) ToJD(y, m, d int) float64 {
+	return islamicEpoch + float64(354*(y-1)+base.FloorDiv(3+11*y, 30)+
+		29*(m-1)+m/2+d)
+}
+
+// FromJD implements Calendar.
+func (c Islamic) FromJD(jd float64) (y, m, d int) {
+	n := dayNumber(jd) - dayNumber(islamicEpoch+1)
+	y = base.FloorDiv(30*n+10646, 10631)
+	n = dayNumber(jd) - dayNumber(c.ToJD(y, 1, 1))
+	// Months alternate 30 and 29 days, except the last month.
+	if m = 2*n/59 + 1; m > 12 {
+		m = 12
+	}
+	d = dayNumber(jd) - dayNumber(c.ToJD(y, m, 1)) + 1
+	return
+}
+
+// MonthName implements Calendar.  Names are those of jm.MMonth.
+func (Islamic) MonthName(y, m int) string { return jm.MMonth(m).String() }
+
+// Persian is the astronomical Persian calendar of package jm.
+//
+// Earth must be a V87Planet object representing Earth, obtained with the
+// package planetposition.
+type Persian struct {
+	Earth *pp.V87Planet
+}
+
+// ToJD implements Calendar.
+func (c Persian) ToJD(y, m, d int) float64 { return jm.PersianToJD(y, m, d, c.Earth) }
+
+// FromJD implements Calendar.
+func (c Persian) FromJD(jd float64) (y, m, d int) { return jm.JDToPersian(jd, c.Earth) }
+
+// MonthName implements Calendar.  Names are those of jm.PMonth.
+func (Persian) MonthName(y, m int) string { return jm.PMonth(m).String() }
+
+// ISOWeek is the ISO 8601 week date calendar.
+//
+// In place of month and day of month, ISOWeek uses the week of the year
+// and day of the week.  Days of the week are numbered from Monday = 1 to
+// Sunday = 7.  Week 1 is the week containing the first Thursday of the
+// Gregorian year.
+type ISOWeek struct{}
+
+// ISOWeekday returns the ISO day of the week, Monday = 1 to Sunday = 7.
+func ISOWeekday(jd float64) int {
+	return (julian.DayOfWeek(jd)+6)%7 + 1
+}
+
+// ToJD implements Calendar.  Arguments are year, week, and day of week.
+func (ISOWeek) ToJD(y, w, d int) float64 {
+	// January 4 is always in week 1.
+	j4 := julian.CalendarGregorianToJD(y, 1, 4)
+	return j4 - float64(ISOWeekday(j4)-1) + float64(7*(w-1)+d-1)
+}
+
+// FromJD implements Calendar.  Results are year, week, and day of week.
+func (c ISOWeek) FromJD(jd float64) (y, w, d int) {
+	y, _, _ = julian.JDToCalendarGregorian(jd)
+	if jd >= c.ToJD(y+1, 1, 1) {
+		y++
+	} else if jd < c.ToJD(y, 1, 1) {
+		y--
+	}
+	n := dayNumber(jd) - dayNumber(c.ToJD(y, 1, 1))
+	return y, n/7 + 1, n%7 + 1
+}
+
+// MonthName implements Calendar.  It returns the ISO designation of
+// week w, such as "W01".
+func (ISOWeek) MonthName(y, w int) string { return fmt.Sprintf("W%02d", w) }
+
+// alexandrian implements calendars with twelve months of 30 days followed
+// by five or six epagomenal days, and a Julian leap year rule.
+//
+// The epoch is the JD of the first day of year 1.
+type alexandrian float64
+
+func (e alexandrian) toJD(y, m, d int) float64 {
+	return float64(e) + float64(365*(y-1)+base.FloorDiv(y, 4)+30*(m-1)+d-1)
+}
+
+func (e alexandrian) fromJD(jd float64) (y, m, d int) {
+	n := dayNumber(jd) - dayNumber(float64(e))
+	y = base.FloorDiv(4*n+1463, 1461)
+	n = dayNumber(jd) - dayNumber(e.toJD(y, 1, 1))
+	return y, n/30 + 1, n%30 + 1
+}
+
+// Coptic is the calendar of the Coptic Church, with epoch 284 August 29
+// in the Julian calendar.
+//
+// Month 13 is the short month of epagomenal days.
+type Coptic struct{}
+
+const copticEpoch alexandrian = 1825029.5
+
+// ToJD implements Calendar.
+func (Coptic) ToJD(y, m, d int) float64 { return copticEpoch.toJD(y, m, d) }
+
+// FromJD implements Calendar.
+func (Coptic) FromJD(jd float64) (y, m, d int) { return copticEpoch.fromJD(jd) }
+
+var cmonths = [13]string{
+	"Thout",
+	"Paopi",
+	"Hathor",
+	"Koiak",
+	"Tobi",
+	"Meshir",
+	"Paremhat",
+	"Parmouti",
+	"Pashons",
+	"Paoni",
+	"Epip",
+	"Mesori",
+	"Pi Kogi Enavot",
+}
+
+// MonthName implements Calendar.
+func (Coptic) MonthName(y, m int) string { return cmonths[m-1] }
+
+// Ethiopian is the Ethiopian calendar, with epoch 8 August 29 in the
+// Julian calendar.
+//
+// Month 13 is the short month of epagomenal days.
+type Ethiopian struct{}
+
+const ethiopianEpoch alexandrian = 1724220.5
+
+// ToJD implements Calendar.
+func (Ethiopian) ToJD(y, m, d int) float64 { return ethiopianEpoch.toJD(y, m, d) }
+
+// FromJD implements Calendar.
+func (Ethiopian) FromJD(jd float64) (y, m, d int) { return ethiopianEpoch.fromJD(jd) }
+
+var emonths = [13]string{
+	"Meskerem",
+	"Tikimt",
+	"Hidar",
+	"Tahsas",
+	"Tir",
+	"Yekatit",
+	"Megabit",
+	"Miyazya",
+	"Ginbot",
+	"Sene",
+	"Hamle",
+	"Nehase",
+	"Pagume",
+}
+
+// MonthName implements Calendar.
+func (Ethiopian) MonthName(y, m int) string { return emonths[m-1] }
+
+// IndianNational is the Indian national calendar, the reformed Saka
+// calendar adopted in 1957.
+//
+// Years are numbered in the Saka era.  The year begins on March 22 of the
+// Gregorian calendar, or March 21 in Gregorian leap years.
+type IndianNational struct{}
+
+// chaitra returns the JD of 1 Chaitra and the length of Chaitra for Saka
+// year y.
+func chaitra(y int) (jd float64, l int) {
+	gy := y + 78
+	if julian.LeapYearGregorian(gy) {
+		return julian.CalendarGregorianToJD(gy, 3, 21), 31
+	}
+	return julian.CalendarGregorianToJD(gy, 3, 22), 30
+}
+
+// ToJD implements Calendar.
+func (IndianNational) ToJD(y, m, d int) float64 {
+	jd, l := chaitra(y)
+	switch {
+	case m == 1:
+	case m <= 6:
+		jd += float64(l + 31*(m-2))
+	default:
+		jd += float64(l + 155 + 30*(m-7))
+	}
+	return jd + float64(d-1)
+}
+
+// FromJD implements Calendar.
+func (IndianNational) FromJD(jd float64) (y, m, d int) {
+	gy, _, _ := julian.JDToCalendarGregorian(jd)
+	y = gy - 78
+	c, l := chaitra(y)
+	if jd < c {
+		y--
+		c, l = chaitra(y)
+	}
+	n := dayNumber(jd) - dayNumber(c)
+	switch {
+	case n < l:
+		return y, 1, n + 1
+	case n < l+155:
+		n -= l
+		return y, n/31 + 2, n%31 + 1
+	}
+	n -= l + 155
+	return y, n/30 + 7, n%30 + 1
+}
+
+var imonths = [12]string{
+	"Chaitra",
+	"Vaishakha",
+	"Jyeshtha",
+	"Ashadha",
+	"Shravana",
+	"Bhadra",
+	"Ashvin",
+	"Kartika",
+	"Agrahayana",
+	"Pausha",
+	"Magha",
+	"Phalguna",
+}
+
+// MonthName implements Calendar.
+func (IndianNational) MonthName(y, m int) string { return imonths[m-1] }
+
+// MayaCorrelation is the JD of the Maya Long Count date 0.0.0.0.0.
+//
+// The value is the "GMT" correlation of Goodman, Martinez, and Thompson,
+// 584283.  The day begins at midnight so the JD of 0h is MayaCorrelation-.5.
+var MayaCorrelation = 584283.
+
+// LongCount is a date of the Maya Long Count.
+type LongCount struct {
+	Baktun, Katun, Tun, Uinal, Kin int
+}
+
+// Days returns the number of days since the Long Count epoch.
+func (lc LongCount) Days() int {
+	return (((lc.Baktun*20+lc.Katun)*20+lc.Tun)*18+lc.Uinal)*20 + lc.Kin
+}
+
+// ToJD returns the JD at 0h of the Long Count date.
+func (lc LongCount) ToJD() float64 {
+	return MayaCorrelation - .5 + float64(lc.Days())
+}
+
+// LongCountFromJD returns the Long Count date of the day containing jd.
+func LongCountFromJD(jd float64) LongCount {
+	n := dayNumber(jd) - int(MayaCorrelation)
+	var lc LongCount
+	lc.Baktun = base.FloorDiv(n, 144000)
+	n -= lc.Baktun * 144000
+	lc.Katun, n = n/7200, n%7200
+	lc.Tun, n = n/360, n%360
+	lc.Uinal, lc.Kin = n/20, n%20
+	return lc
+}
+
+// String returns the conventional notation of the date, such as "13.0.0.0.0".
+func (lc LongCount) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d.%d",
+		lc.Baktun, lc.Katun, lc.Tun, lc.Uinal, lc.Kin)
+}
@@ -0,0 +1,177 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package calendar_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yanjunhui/meeus/calendar"
+	"github.com/yanjunhui/meeus/julian"
+)
+
+func Example() {
+	jd := julian.CalendarGregorianToJD(2000, 1, 1)
+	for _, c := range []struct {
+		name string
+		cal  calendar.Calendar
+	}{
+		{"Gregorian", calendar.Gregorian{}},
+		{"Julian", calendar.Julian{}},
+		{"Hebrew", calendar.Hebrew{}},
+		{"Islamic", calendar.Islamic{}},
+		{"ISO week", calendar.ISOWeek{}},
+		{"Coptic", calendar.Coptic{}},
+		{"Ethiopian", calendar.Ethiopian{}},
+		{"Indian", calendar.IndianNational{}},
+	} {
+		y, m, d := c.cal.FromJD(jd)
+		fmt.Printf("%-10s %d %s %d\n", c.name+":", d, c.cal.MonthName(y, m), y)
+	}
+	fmt.Println("Maya:     ", calendar.LongCountFromJD(jd))
+	// Output:
+	// Gregorian: 1 January 2000
+	// Julian:    19 December 1999
+	// Hebrew:    23 Ṭevet 5760
+	// Islamic:   24 Ramaḍān 1420
+	// ISO week:  6 W52 1999
+	// Coptic:    22 Koiak 1716
+	// Ethiopian: 22 Tahsas 1992
+	// Indian:    11 Pausha 1921
+	// Maya:      12.19.6.15.2
+}
+
+func ExampleHebrew() {
+	// Example 9.a, p. 73:  Pesach is 15 Nisan, the New Year is 1 Tishri.
+	var h calendar.Hebrew
+	var g calendar.Gregorian
+	y, m, d := g.FromJD(h.ToJD(5750, 7, 15))
+	fmt.Println("Pesach:  ", y, m, d)
+	y, m, d = g.FromJD(h.ToJD(5751, 1, 1))
+	fmt.Println("New Year:", y, m, d)
+	// Output:
+	// Pesach:   1990 4 10
+	// New Year: 1990 9 20
+}
+
+func ExampleIslamic() {
+	// Example 9.b, p. 75.
+	var g calendar.Gregorian
+	y, m, d := g.FromJD(calendar.Islamic{}.ToJD(1421, 1, 1))
+	fmt.Println(y, m, d)
+	// Output:
+	// 2000 4 6
+}
+
+func ExampleLongCount() {
+	lc := calendar.LongCount{Baktun: 13}
+	y, m, d := calendar.Gregorian{}.FromJD(lc.ToJD())
+	fmt.Println(lc, ":", y, m, d)
+	// Output:
+	// 13.0.0.0.0 : 2012 12 21
+}
+
+// test that dates round trip for a range of days.
+func TestRoundTrip(t *testing.T) {
+	for _, cal := range []calendar.Calendar{
+		calendar.Gregorian{},
+		calendar.Julian{},
+		calendar.Hebrew{},
+		calendar.Islamic{},
+		calendar.ISOWeek{},
+		calendar.Coptic{},
+		calendar.Ethiopian{},
+		calendar.IndianNational{},
+	} {
+		y0, m0, d0 := cal.FromJD(2451544.5 - 1)
+		for jd := 2451544.5; jd < 2451544.5+3000; jd++ {
+			y, m, d := cal.FromJD(jd)
+			if cal.ToJD(y, m, d) != jd {
+				t.Fatalf("%T: %.1f -> %d %d %d -> %.1f",
+					cal, jd, y, m, d, cal.ToJD(y, m, d))
+			}
+			// consecutive days must be consecutive dates
+			if !(y == y0 && m == m0 && d == d0+1 ||
+				y == y0 && m == m0+1 && d == 1 ||
+				y == y0+1 && m == 1 && d == 1) {
+				t.Fatalf("%T: %.1f -> %d %d %d follows %d %d %d",
+					cal, jd, y, m, d, y0, m0, d0)
+			}
+			y0, m0, d0 = y, m, d
+		}
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	for _, tp := range []struct {
+		y, m, d    int
+		iy, iw, id int
+	}{
+		{2005, 1, 1, 2004, 53, 6},
+		{2007, 12, 31, 2008, 1, 1},
+		{2008, 12, 28, 2008, 52, 7},
+		{2009, 12, 31, 2009, 53, 4},
+		{2010, 1, 3, 2009, 53, 7},
+	} {
+		jd := julian.CalendarGregorianToJD(tp.y, tp.m, float64(tp.d))
+		y, w, d := calendar.ISOWeek{}.FromJD(jd)
+		if y != tp.iy || w != tp.iw || d != tp.id {
+			t.Errorf("%d-%02d-%02d: got %d-W%02d-%d", tp.y, tp.m, tp.d, y, w, d)
+		}
+	}
+}
+
+func ExampleHistorical() {
+	// The reform in Britain, 1752.
+	jd := julian.CalendarGregorianToJD(1752, 9, 14)
+	for _, d := range []float64{jd - 1, jd} {
+		y, m, d := calendar.Britain.FromJD(d)
+		fmt.Println(y, time.Month(m), d)
+	}
+	_, err := calendar.Britain.CheckedToJD(1752, 9, 10)
+	fmt.Println(err)
+	// Output:
+	// 1752 September 2
+	// 1752 September 14
+	// Day omitted by calendar reform
+}
+
+func ExampleEraYear() {
+	y, e := calendar.EraYear(-43)
+	fmt.Println(y, e, calendar.AstronomicalYear(y, e))
+	// Output:
+	// 44 BC -43
+}
+
+func TestHistorical(t *testing.T) {
+	// Papal agrees with julian.JDToCalendar across the reform.
+	for jd := 2299160.5 - 400; jd < 2299160.5+400; jd++ {
+		y, m, d := calendar.Papal.FromJD(jd)
+		jy, jm, jd0 := julian.JDToCalendar(jd)
+		if y != jy || m != jm || d != int(jd0) {
+			t.Fatalf("%.1f: %d %d %d, julian %d %d %v", jd, y, m, d, jy, jm, jd0)
+		}
+		if calendar.Papal.ToJD(y, m, d) != jd {
+			t.Fatalf("%.1f: round trip %d %d %d", jd, y, m, d)
+		}
+	}
+	// Russia: Julian 1918 Jan 31 followed by Gregorian Feb 14.
+	jd := calendar.Russia.ToJD(1918, 1, 31)
+	if y, m, d := calendar.Russia.FromJD(jd + 1); y != 1918 || m != 2 || d != 14 {
+		t.Fatal("Russia:", y, m, d)
+	}
+	for d := 1; d <= 13; d++ {
+		if _, err := calendar.Russia.CheckedToJD(1918, 2, d); err != calendar.ErrorMissingDay {
+			t.Fatal("Russia: Feb", d, err)
+		}
+	}
+	if _, err := calendar.Russia.CheckedToJD(1918, 2, 14); err != nil {
+		t.Fatal(err)
+	}
+	// Britain kept Julian leap day 1700 Feb 29.
+	if _, err := calendar.Britain.CheckedToJD(1700, 2, 29); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package calendar
+
+import (
+	"errors"
+	"time"
+
+	"github.com/yanjunhui/meeus/julian"
+)
+
+// Historical is the civil calendar of a country that changed from the
+// Julian to the Gregorian calendar.
+//
+// Reform is the JD of the first day reckoned in the Gregorian calendar.
+// Dates before it are reckoned in the Julian calendar.  The days omitted
+// at the change have no date in this calendar.
+//
+// Years are numbered astronomically, with year 0 preceding year 1.  See
+// AstronomicalYear and EraYear for conversion to and from BC and AD year
+// numbers.  Differences in the start of the year, such as the Old Style
+// year of Britain beginning on March 25, are not modeled.
+type Historical struct {
+	Reform float64
+}
+
+// Papal is the calendar of countries that adopted the Gregorian calendar
+// at its introduction, with 1582 October 4 followed by October 15.  It
+// converts dates the same as julian.JDToCalendar.
+var Papal = Historical{julian.CalendarGregorianToJD(1582, 10, 15)}
+
+// Britain is the calendar of Great Britain and its colonies, with 1752
+// September 2 followed by September 14.
+var Britain = Historical{julian.CalendarGregorianToJD(1752, 9, 14)}
+
+// Russia is the calendar of Russia, with 1918 January 31 followed by
+// February 14.
+var Russia = Historical{julian.CalendarGregorianToJD(1918, 2, 14)}
+
+// ErrorMissingDay is returned for dates omitted at a calendar reform.
+var ErrorMissingDay = errors.New("Day omitted by calendar reform")
+
+// ToJD implements Calendar.
+//
+// A date omitted at the reform is reckoned in the Julian calendar, giving
+// a JD on or after the reform.  Use CheckedToJD to detect these dates.
+func (c Historical) ToJD(y, m, d int) float64 {
+	if jd := julian.CalendarGregorianToJD(y, m, float64(d)); dayNumber(jd) >= dayNumber(c.Reform) {
+		return jd
+	}
+	return julian.CalendarJulianToJD(y, m, float64(d))
+}
+
+// CheckedToJD is like ToJD but returns ErrorMissingDay for a date omitted
+// at the reform.
+func (c Historical) CheckedToJD(y, m, d int) (float64, error) {
+	jd := c.ToJD(y, m, d)
+	// A Julian date on or after the reform is one omitted by the reform.
+	if dayNumber(jd) >= dayNumber(c.Reform) &&
+		dayNumber(julian.CalendarGregorianToJD(y, m, float64(d))) < dayNumber(c.Reform) {
+		return jd, ErrorMissingDay
+	}
+	return jd, nil
+}
+
+// FromJD implements Calendar.
+func (c Historical) FromJD(jd float64) (y, m, d int) {
+	var df float64
+	if dayNumber(jd) >= dayNumber(c.Reform) {
+		y, m, df = julian.JDToCalendarGregorian(jd)
+	} else {
+		y, m, df = julian.JDToCalendarJulian(jd)
+	}
+	return y, m, int(df)
+}
+
+// MonthName implements Calendar.  Names are those of the time package.
+func (Historical) MonthName(y, m int) string { return time.Month(m).String() }
+
+// Era distinguishes years before and after the start of the Christian era.
+type Era int
+
+const (
+	AD Era = iota
+	BC
+)
+
+// String returns "AD" or "BC".
+func (e Era) String() string {
+	if e == BC {
+		return "BC"
+	}
+	return "AD"
+}
+
+// AstronomicalYear returns the astronomical year number corresponding to
+// year y of era e.  1 BC is year 0, 2 BC is year -1, and so on.
+func AstronomicalYear(y int, e Era) int {
+	if e == BC {
+		return 1 - y
+	}
+	return y
+}
+
+// EraYear returns the year and era corresponding to astronomical year y.
+func EraYear(y int) (int, Era) {
+	if y < 1 {
+		return 1 - y, BC
+	}
+	return y, AD
+}
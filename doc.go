@@ -87,6 +87,17 @@
 // as helper subroutines or IO subroutines.  The functions do not offer
 // additional astronomy algorithms beyond those provided by Meeus.
 //
+// A few more packages build on the chapter packages to provide
+// functionality not found in the book.  These are:
+//
+//	calendar     A common interface to calendar conversions, including
+//	             historical Julian to Gregorian reforms
+//	feast        Movable feasts of the Christian calendar
+//	occultation  Lunar occultations of stars and planets
+//	prayer       Islamic prayer times and Jewish zmanim
+//	supermoon    New and Full Moons near perigee and apogee
+//	transit      Transits of Mercury and Venus
+//
 // # Identifiers
 //
 // To more closely follow the book's use of Greek letters and other symbols,
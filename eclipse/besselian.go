@@ -0,0 +1,87 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Radii in units of the equatorial radius of the Earth.
+const (
+	kPenumbra = .2725076 // Moon, for the penumbral cone
+	kUmbra    = .272281  // Moon, for the umbral cone
+	sunRadius = 109.1214 // Sun, 959.63″ at 1 AU
+)
+
+// Besselian holds Besselian elements of a solar eclipse at an instant.
+//
+// The fundamental plane passes through the center of the Earth,
+// perpendicular to the axis of the Moon's shadow.  Distances are in units
+// of the equatorial radius of the Earth.
+type Besselian struct {
+	X, Y   float64    // coordinates of the shadow axis in the fundamental plane
+	D      unit.Angle // declination of the shadow axis
+	Mu     unit.Angle // Greenwich hour angle of the shadow axis, μ
+	L1, L2 float64    // radii of the penumbral and umbral cones in the fundamental plane
+	F1, F2 unit.Angle // half-angles of the penumbral and umbral cones
+}
+
+// BesselianElements computes Besselian elements at time jde.
+//
+// The elements are computed from the low precision solar position of
+// package solar and the lunar position of package moonposition.  L2 is
+// negative where the umbral cone reaches the fundamental plane, that is,
+// for a total eclipse.
+func BesselianElements(jde float64) (b Besselian) {
+	αs, δs := solar.ApparentEquatorial(jde)
+	rs := solar.Radius(base.J2000Century(jde)) * base.AU / globe.Earth76.Er
+	αm, δm, Δ := moonEquatorial(jde)
+	rm := Δ / globe.Earth76.Er
+	// vector from Moon to Sun
+	sαs, cαs := αs.Sincos()
+	sδs, cδs := δs.Sincos()
+	sαm, cαm := αm.Sincos()
+	sδm, cδm := δm.Sincos()
+	gx := rs*cδs*cαs - rm*cδm*cαm
+	gy := rs*cδs*sαs - rm*cδm*sαm
+	gz := rs*sδs - rm*sδm
+	g := math.Sqrt(gx*gx + gy*gy + gz*gz)
+	a := unit.RAFromRad(math.Atan2(gy, gx))
+	b.D = unit.Angle(math.Asin(gz / g))
+	sd, cd := b.D.Sincos()
+	sH, cH := math.Sincos(αm.Rad() - a.Rad())
+	b.X = rm * cδm * sH
+	b.Y = rm * (sδm*cd - cδm*sd*cH)
+	z := rm * (sδm*sd + cδm*cd*cH)
+	b.F1 = unit.Angle(math.Asin((sunRadius + kPenumbra) / g))
+	b.F2 = unit.Angle(math.Asin((sunRadius - kUmbra) / g))
+	b.L1 = z*b.F1.Tan() + kPenumbra/b.F1.Cos()
+	b.L2 = z*b.F2.Tan() - kUmbra/b.F2.Cos()
+	jd := jde - deltat.Interp10A(jde).Day()
+	b.Mu = unit.Angle(sidereal.Apparent(jd).Rad() - a.Rad()).Mod1()
+	return
+}
+
+// local returns the position of the observer relative to the shadow axis
+// and the radii of the penumbra and umbra in the plane of the observer.
+//
+// Arguments s and c are parallax constants ρ sin φ′ and ρ cos φ′,
+// L is longitude of the observer, positive west.
+func (b *Besselian) local(s, c float64, L unit.Angle) (u, v, L1, L2 float64) {
+	sH, cH := (b.Mu - L).Sincos()
+	sd, cd := b.D.Sincos()
+	ξ := c * sH
+	η := s*cd - c*cH*sd
+	ζ := s*sd + c*cH*cd
+	L1 = b.L1 - ζ*b.F1.Tan()
+	L2 = b.L2 - ζ*b.F2.Tan()
+	return b.X - ξ, b.Y - η, L1, L2
+}
@@ -0,0 +1,120 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/moonposition"
+	"github.com/yanjunhui/meeus/nutation"
+	"github.com/yanjunhui/meeus/rise"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Contact identifies a contact of a lunar eclipse.
+type Contact int
+
+// Contacts in chronological order.  P1 and P4 are first and last contact
+// with the penumbra, U1 and U4 with the umbra.  U2 and U3 are beginning
+// and end of totality.
+const (
+	P1 Contact = iota
+	U1
+	U2
+	U3
+	U4
+	P4
+)
+
+var contactNames = [...]string{"P1", "U1", "U2", "U3", "U4", "P4"}
+
+// String returns the conventional designation of the contact, such as "U1".
+func (c Contact) String() string { return contactNames[c] }
+
+// LunarContact holds the circumstances of a single contact of a lunar
+// eclipse.
+type LunarContact struct {
+	Contact Contact
+	JDE     float64     // dynamical time
+	JD      float64     // universal time
+	Sub     globe.Coord // sub-lunar point, longitude positive west
+	h0      unit.Angle  // standard altitude of the Moon
+}
+
+// Contacts returns the contacts of the eclipse in chronological order.
+//
+// Only contacts that occur for the eclipse type are returned; a penumbral
+// eclipse has just P1 and P4, an umbral eclipse has P1, U1, U4, and P4.
+//
+// Times are computed from JMax and the semidurations of Lunar and so have
+// the accuracy of those values, typically a few minutes.
+func (e *LunarEclipse) Contacts() []LunarContact {
+	if e.Type == None {
+		return nil
+	}
+	cs := make([]LunarContact, 0, 6)
+	add := func(c Contact, sd unit.Time) {
+		cs = append(cs, lunarContact(c, e.JMax+sd.Day()))
+	}
+	add(P1, -e.SdPenumbral)
+	if e.Type != Penumbral {
+		add(U1, -e.SdPartial)
+	}
+	if e.Type == Total {
+		add(U2, -e.SdTotal)
+		add(U3, e.SdTotal)
+	}
+	if e.Type != Penumbral {
+		add(U4, e.SdPartial)
+	}
+	add(P4, e.SdPenumbral)
+	return cs
+}
+
+// moonEquatorial returns apparent geocentric equatorial coordinates of
+// the Moon and its distance in km.
+func moonEquatorial(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	λ, β, Δ := moonposition.Position(jde)
+	Δψ, Δε := nutation.Nutation(jde)
+	ε := nutation.MeanObliquity(jde) + Δε
+	sε, cε := ε.Sincos()
+	α, δ = coord.EclToEq(λ+Δψ, β, sε, cε)
+	return
+}
+
+func lunarContact(c Contact, jde float64) LunarContact {
+	jd := jde - deltat.Interp10A(jde).Day()
+	α, δ, Δ := moonEquatorial(jde)
+	// sub-lunar point is where the hour angle is zero
+	L := unit.Angle(math.Remainder(
+		sidereal.Apparent(jd).Rad()-α.Rad(), 2*math.Pi))
+	return LunarContact{
+		Contact: c,
+		JDE:     jde,
+		JD:      jd,
+		Sub:     globe.Coord{Lat: δ, Lon: L},
+		h0:      rise.Stdh0Lunar(moonposition.Parallax(Δ)),
+	}
+}
+
+// Altitude returns the geocentric altitude of the Moon at the contact,
+// as seen from an observer at p.
+func (c *LunarContact) Altitude(p globe.Coord) unit.Angle {
+	sφ, cφ := p.Lat.Sincos()
+	sδ, cδ := c.Sub.Lat.Sincos()
+	return unit.Angle(math.Asin(sφ*sδ + cφ*cδ*(c.Sub.Lon-p.Lon).Cos()))
+}
+
+// Visible returns true if the Moon is above the horizon at the contact,
+// as seen from an observer at p.
+//
+// The test uses the standard altitude of the Moon, accounting for parallax,
+// semidiameter, and refraction at the horizon.
+func (c *LunarContact) Visible(p globe.Coord) bool {
+	return c.Altitude(p) > c.h0
+}
@@ -2,6 +2,10 @@
 // License: MIT
 
 // Eclipse: Chapter 54, Eclipses.
+//
+// Beyond the book, the package enumerates eclipses over a range of dates
+// and computes lunar eclipse contacts, Besselian elements, local
+// circumstances and paths of solar eclipses, and Saros and Inex series.
 package eclipse
 
 import (
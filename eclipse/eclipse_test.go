@@ -4,9 +4,16 @@
 package eclipse_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"testing"
 
 	"github.com/yanjunhui/meeus/eclipse"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/sexa"
+	"github.com/yanjunhui/meeus/unit"
 )
 
 func ExampleSolar_1993() {
@@ -184,3 +191,356 @@ func ExampleLunar_1997() {
 	// Partial phase semiduration:     98 min
 	// Penumbral semiduration:        153 min
 }
+
+func typeName(t int) string {
+	return [...]string{"none", "partial", "annular", "annular-total",
+		"penumbral", "umbral", "total"}[t]
+}
+
+func ExampleSolarEclipses() {
+	// Solar eclipses of 2024.
+	jde0 := julian.CalendarGregorianToJD(2024, 1, 1)
+	jde1 := julian.CalendarGregorianToJD(2025, 1, 1)
+	for _, e := range eclipse.SolarEclipses(jde0, jde1) {
+		y, m, d := julian.JDToCalendar(e.JMax)
+		fmt.Printf("%d-%02d-%02d %s\n", y, m, int(d), typeName(e.Type))
+	}
+	// Output:
+	// 2024-04-08 total
+	// 2024-10-02 annular
+}
+
+func ExampleLunarEclipses() {
+	// Lunar eclipses of 2025.
+	jde0 := julian.CalendarGregorianToJD(2025, 1, 1)
+	jde1 := julian.CalendarGregorianToJD(2026, 1, 1)
+	for _, e := range eclipse.LunarEclipses(jde0, jde1) {
+		y, m, d := julian.JDToCalendar(e.JMax)
+		fmt.Printf("%d-%02d-%02d %s %.3f\n",
+			y, m, int(d), typeName(e.Type), e.Mag)
+	}
+	// Output:
+	// 2025-03-14 total 1.175
+	// 2025-09-07 total 1.361
+}
+
+func ExampleLunarEclipse_Contacts() {
+	// Total lunar eclipse of 2025 September 7.
+	jde := julian.CalendarGregorianToJD(2025, 9, 7)
+	e := eclipse.LunarEclipses(jde, jde+1)[0]
+	delhi := globe.Coord{
+		Lat: unit.NewAngle(' ', 28, 37, 0),
+		Lon: unit.NewAngle('-', 77, 12, 0),
+	}
+	london := globe.Coord{
+		Lat: unit.NewAngle(' ', 51, 30, 0),
+		Lon: unit.NewAngle(' ', 0, 8, 0),
+	}
+	for _, c := range e.Contacts() {
+		fmt.Printf("%s %.0d UT  %+5.1f %+6.1f  %-5t %t\n", c.Contact,
+			sexa.FmtTime(unit.TimeFromDay(math.Mod(c.JD+.5, 1))),
+			c.Sub.Lat.Deg(), c.Sub.Lon.Deg(),
+			c.Visible(delhi), c.Visible(london))
+	}
+	// Output:
+	// P1 15ʰ29ᵐ45ˢ UT   -6.8 -125.8  true  false
+	// U1 16ʰ28ᵐ3ˢ UT   -6.5 -111.7  true  false
+	// U2 17ʰ31ᵐ20ˢ UT   -6.2  -96.4  true  false
+	// U3 18ʰ52ᵐ48ˢ UT   -5.8  -76.7  true  true
+	// U4 19ʰ56ᵐ5ˢ UT   -5.5  -61.4  true  true
+	// P4 20ʰ54ᵐ23ˢ UT   -5.2  -47.3  true  true
+}
+
+func ExampleSolarEclipse_Local() {
+	// Total solar eclipse of 2024 April 8, seen from Dallas and New York.
+	jde := julian.CalendarGregorianToJD(2024, 4, 8)
+	e := eclipse.SolarEclipses(jde, jde+1)[0]
+	for _, p := range []struct {
+		name string
+		globe.Coord
+	}{
+		{"Dallas", globe.Coord{
+			Lat: unit.NewAngle(' ', 32, 47, 0),
+			Lon: unit.NewAngle(' ', 96, 48, 0),
+		}},
+		{"New York", globe.Coord{
+			Lat: unit.NewAngle(' ', 40, 43, 0),
+			Lon: unit.NewAngle(' ', 74, 0, 0),
+		}},
+	} {
+		l, ok := e.Local(p.Coord, 0)
+		if !ok {
+			continue
+		}
+		fmt.Printf("%s: %s, magnitude %.3f, obscuration %.3f\n",
+			p.name, typeName(l.Type), l.Mag, l.Obscuration)
+		for _, c := range []struct {
+			name string
+			eclipse.SolarContact
+		}{
+			{"C1", l.C1}, {"C2", l.C2}, {"Max", l.Max}, {"C3", l.C3}, {"C4", l.C4},
+		} {
+			if c.JD == 0 {
+				continue
+			}
+			fmt.Printf("  %-3s %.0d UT  alt %.0f°  az %.0f°\n", c.name,
+				sexa.FmtTime(unit.TimeFromDay(math.Mod(c.JD+.5, 1))),
+				c.Alt.Deg(), c.Az.Deg())
+		}
+	}
+	// Output:
+	// Dallas: total, magnitude 1.018, obscuration 1.000
+	//   C1  17ʰ23ᵐ58ˢ UT  alt 61°  az -34°
+	//   C2  18ʰ41ᵐ17ˢ UT  alt 65°  az 7°
+	//   Max 18ʰ43ᵐ19ˢ UT  alt 65°  az 8°
+	//   C3  18ʰ45ᵐ22ˢ UT  alt 65°  az 10°
+	//   C4  20ʰ3ᵐ21ˢ UT  alt 57°  az 46°
+	// New York: partial, magnitude 0.908, obscuration 0.896
+	//   C1  18ʰ11ᵐ18ˢ UT  alt 53°  az 31°
+	//   Max 19ʰ26ᵐ15ˢ UT  alt 43°  az 55°
+	//   C4  20ʰ37ᵐ0ˢ UT  alt 31°  az 71°
+}
+
+func ExampleSolarEclipse_Path() {
+	// Central line of the total solar eclipse of 2024 April 8,
+	// at half hour intervals.
+	jde := julian.CalendarGregorianToJD(2024, 4, 8)
+	e := eclipse.SolarEclipses(jde, jde+1)[0]
+	p := e.Path(1. / 48)
+	for _, l := range p.Central {
+		for _, pt := range l {
+			fmt.Printf("%.4f  %+6.2f %+7.2f\n",
+				pt.JDE, pt.Lat.Deg(), pt.Lon.Deg())
+		}
+	}
+	j, err := p.GeoJSON()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var g struct {
+		Features []struct {
+			Geometry struct {
+				Type string
+			}
+			Properties struct {
+				Name string
+			}
+		}
+	}
+	json.Unmarshal(j, &g)
+	for _, f := range g.Features {
+		fmt.Printf("%s: %s\n", f.Properties.Name, f.Geometry.Type)
+	}
+	// Output:
+	// 2460409.2006   -3.18 +139.98
+	// 2460409.2215   +7.51 +121.77
+	// 2460409.2423  +16.64 +112.33
+	// 2460409.2631  +25.31 +104.30
+	// 2460409.2840  +33.80  +95.00
+	// 2460409.3048  +42.11  +80.88
+	// 2460409.3256  +48.93  +49.40
+	// central: MultiLineString
+	// north: MultiLineString
+	// south: MultiLineString
+	// penumbral north: MultiLineString
+	// penumbral south: MultiLineString
+	// umbra: MultiPolygon
+}
+
+func ExampleSolarEclipse_Saros() {
+	for _, y := range []int{2017, 2024} {
+		jde := julian.CalendarGregorianToJD(y, 1, 1)
+		for _, e := range eclipse.SolarEclipses(jde, jde+365) {
+			s, i := e.Saros()
+			n, of := e.Member()
+			y, m, d := julian.JDToCalendar(e.JMax)
+			fmt.Printf("%d-%02d-%02d  Saros %d, member %d of %d, Inex %d\n",
+				y, m, int(d), s, n, of, i)
+		}
+	}
+	// Output:
+	// 2017-02-26  Saros 140, member 29 of 71, Inex 58
+	// 2017-08-21  Saros 145, member 22 of 77, Inex 50
+	// 2024-04-08  Saros 139, member 30 of 71, Inex 60
+	// 2024-10-02  Saros 144, member 17 of 70, Inex 52
+}
+
+func ExampleLunarEclipse_Saros() {
+	jde := julian.CalendarGregorianToJD(2025, 1, 1)
+	for _, e := range eclipse.LunarEclipses(jde, jde+365) {
+		s, _ := e.Saros()
+		n, of := e.Member()
+		y, m, d := julian.JDToCalendar(e.JMax)
+		fmt.Printf("%d-%02d-%02d  Saros %d, member %d of %d\n",
+			y, m, int(d), s, n, of)
+	}
+	// Output:
+	// 2025-03-14  Saros 123, member 53 of 72
+	// 2025-09-07  Saros 128, member 41 of 71
+}
+
+func ExampleSolarSaros() {
+	es := eclipse.SolarSaros(145)
+	for _, e := range []eclipse.SolarEclipse{es[0], es[len(es)-1]} {
+		y, m, d := julian.JDToCalendar(e.JMax)
+		fmt.Printf("%d-%02d-%02d %s\n", y, m, int(d), typeName(e.Type))
+	}
+	// walk from 2017 to the next eclipse of the series
+	e, _ := eclipse.SolarK(218)
+	e, _ = e.SarosNext()
+	y, m, d := julian.JDToCalendar(e.JMax)
+	fmt.Printf("%d-%02d-%02d %s\n", y, m, int(d), typeName(e.Type))
+	// Output:
+	// 1639-01-04 partial
+	// 3009-04-17 partial
+	// 2035-09-02 total
+}
+
+func TestPathGeoJSON(t *testing.T) {
+	// 2024 April 8 begins in the Pacific, 2012 May 20 crosses the
+	// antimeridian.
+	crossed := false
+	for _, d := range [][3]int{{2024, 4, 8}, {2012, 5, 20}} {
+		jde := julian.CalendarGregorianToJD(d[0], d[1], float64(d[2]))
+		p := eclipse.SolarEclipses(jde, jde+1)[0].Path(1. / 1440)
+		j, err := p.GeoJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var g struct {
+			Features []struct {
+				Geometry struct {
+					Type        string
+					Coordinates json.RawMessage
+				}
+				Properties struct {
+					Name string
+				}
+			}
+		}
+		if err := json.Unmarshal(j, &g); err != nil {
+			t.Fatal(err)
+		}
+		// check checks a line or ring of a feature
+		check := func(name string, l [][2]float64) {
+			for i, x := range l {
+				if math.Abs(x[0]) > 180 || math.Abs(x[1]) > 90 {
+					t.Fatal(d, name, "position out of range", x)
+				}
+				if math.Abs(x[0]) == 180 {
+					crossed = true
+				}
+				if i > 0 && math.Abs(x[0]-l[i-1][0]) > 180 {
+					t.Fatal(d, name, "crosses antimeridian", l[i-1], x)
+				}
+			}
+		}
+		for _, f := range g.Features {
+			name := f.Properties.Name
+			switch f.Geometry.Type {
+			case "MultiLineString":
+				var ls [][][2]float64
+				json.Unmarshal(f.Geometry.Coordinates, &ls)
+				for _, l := range ls {
+					if len(l) < 2 {
+						t.Fatal(d, name, "line of", len(l), "positions")
+					}
+					check(name, l)
+				}
+			case "MultiPolygon":
+				var ps [][][][2]float64
+				json.Unmarshal(f.Geometry.Coordinates, &ps)
+				if len(ps) == 0 {
+					t.Fatal(d, name, "no polygons")
+				}
+				for _, p := range ps {
+					r := p[0]
+					if len(r) < 4 || r[0] != r[len(r)-1] {
+						t.Fatal(d, name, "ring not closed")
+					}
+					check(name, r)
+					if selfIntersects(r) {
+						t.Fatal(d, name, "ring self-intersects")
+					}
+				}
+			default:
+				t.Fatal(d, name, "unexpected type", f.Geometry.Type)
+			}
+		}
+	}
+	if !crossed {
+		t.Fatal("no line split at the antimeridian")
+	}
+}
+
+func TestPathStep(t *testing.T) {
+	jde := julian.CalendarGregorianToJD(2024, 4, 8)
+	e := eclipse.SolarEclipses(jde, jde+1)[0]
+	for _, step := range []float64{0, -1. / 48, math.NaN()} {
+		if p := e.Path(step); p.Central != nil || p.PenNorth != nil {
+			t.Fatal("path for step", step)
+		}
+	}
+}
+
+// selfIntersects reports whether non-adjacent edges of closed ring r cross.
+func selfIntersects(r [][2]float64) bool {
+	cross := func(o, a, b [2]float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+	n := len(r) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			a, b, c, e := r[i], r[i+1], r[j], r[j+1]
+			if cross(a, b, c)*cross(a, b, e) < 0 &&
+				cross(c, e, a)*cross(c, e, b) < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestSarosMember(t *testing.T) {
+	// Member agrees with the series, including at its ends.
+	for _, s := range []int{1, 117, 145, 180} {
+		es := eclipse.SolarSaros(s)
+		if len(es) == 0 {
+			t.Fatal("solar Saros", s, "empty")
+		}
+		for i := range es {
+			if n, of := es[i].Member(); n != i+1 || of != len(es) {
+				t.Fatalf("solar Saros %d: member %d of %d, got %d of %d",
+					s, i+1, len(es), n, of)
+			}
+		}
+		if sr := es[len(es)/2].Series(); len(sr) != len(es) || sr[0] != es[0] {
+			t.Fatal("solar Saros", s, "Series differs")
+		}
+		if _, ok := es[0].SarosPrev(); ok {
+			t.Fatal("solar Saros", s, "eclipse before first member")
+		}
+		if _, ok := es[len(es)-1].SarosNext(); ok {
+			t.Fatal("solar Saros", s, "eclipse after last member")
+		}
+	}
+	for _, s := range []int{1, 124, 150} {
+		es := eclipse.LunarSaros(s)
+		if len(es) == 0 {
+			t.Fatal("lunar Saros", s, "empty")
+		}
+		for i := range es {
+			if n, of := es[i].Member(); n != i+1 || of != len(es) {
+				t.Fatalf("lunar Saros %d: member %d of %d, got %d of %d",
+					s, i+1, len(es), n, of)
+			}
+		}
+		if sr := es[len(es)/2].Series(); len(sr) != len(es) || sr[0] != es[0] {
+			t.Fatal("lunar Saros", s, "Series differs")
+		}
+	}
+}
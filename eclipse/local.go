@@ -0,0 +1,141 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/iterate"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// SolarContact holds circumstances of a contact or of maximum of a solar
+// eclipse for a local observer.
+type SolarContact struct {
+	JDE float64    // dynamical time
+	JD  float64    // universal time
+	Alt unit.Angle // altitude of the Sun, without refraction
+	Az  unit.Angle // azimuth of the Sun, measured westward from the South
+}
+
+// LocalSolar holds local circumstances of a solar eclipse.
+type LocalSolar struct {
+	Type        int // Partial, Annular, or Total, as seen by the observer
+	C1, C2      SolarContact
+	Max         SolarContact
+	C3, C4      SolarContact // C2 and C3 are zero for a partial eclipse
+	Mag         float64      // magnitude at maximum
+	Obscuration float64      // fraction of the solar disk covered at maximum
+}
+
+// search limits, days
+const (
+	localStep  = 1. / 144 // 10 minutes
+	localRange = .25      // from jmax
+)
+
+// Local computes local circumstances of the eclipse for an observer at p,
+// with height h in meters above the ellipsoid.
+//
+// Circumstances are found by evaluating BesselianElements at successive
+// times, and so are of the accuracy of that function, typically within
+// a minute or so of time.
+//
+// Result ok is false if the eclipse is not seen at p.  Results are computed
+// regardless of whether the Sun is above the horizon; check the altitudes
+// of the contacts to see what part of the eclipse is observable.
+func (e *SolarEclipse) Local(p globe.Coord, h float64) (l LocalSolar, ok bool) {
+	if e.Type == None {
+		return
+	}
+	s, c := globe.Earth76.ParallaxConstants(p.Lat, h)
+	at := func(jde float64) (m, L1, L2 float64) {
+		b := BesselianElements(jde)
+		u, v, L1, L2 := b.local(s, c, p.Lon)
+		return math.Hypot(u, v), L1, L2
+	}
+	dist := func(jde float64) float64 {
+		m, _, _ := at(jde)
+		return m
+	}
+	pen := func(jde float64) float64 {
+		m, L1, _ := at(jde)
+		return m - L1
+	}
+	umb := func(jde float64) float64 {
+		m, _, L2 := at(jde)
+		return m - math.Abs(L2)
+	}
+	// coarse scan for the closest approach of the shadow axis, then refine
+	tMax, mMin := e.JMax, math.Inf(1)
+	for t := e.JMax - localRange; t <= e.JMax+localRange; t += localStep {
+		if m := dist(t); m < mMin {
+			tMax, mMin = t, m
+		}
+	}
+	tMax = iterate.GoldenMin(dist, tMax-localStep, tMax+localStep, 1e-7)
+	m, L1, L2 := at(tMax)
+	if m >= L1 {
+		return // observer never in penumbra
+	}
+	contact := func(jde float64) SolarContact {
+		jd := jde - deltat.Interp10A(jde).Day()
+		α, δ := solar.ApparentEquatorial(jde)
+		A, h := coord.EqToHz(α, δ, p.Lat, p.Lon, sidereal.Apparent(jd))
+		return SolarContact{JDE: jde, JD: jd, Alt: h, Az: A}
+	}
+	l.Max = contact(tMax)
+	l.C1 = contact(iterate.BinaryRoot(pen, outside(pen, tMax, -localStep), tMax))
+	l.C4 = contact(iterate.BinaryRoot(pen, tMax, outside(pen, tMax, localStep)))
+	l.Type = Partial
+	if m < math.Abs(L2) {
+		l.Type = Annular
+		if L2 < 0 {
+			l.Type = Total
+		}
+		step := localStep / 10
+		l.C2 = contact(iterate.BinaryRoot(umb, outside(umb, tMax, -step), tMax))
+		l.C3 = contact(iterate.BinaryRoot(umb, tMax, outside(umb, tMax, step)))
+	}
+	l.Mag = (L1 - m) / (L1 + L2)
+	l.Obscuration = obscuration((L1-L2)/(L1+L2), l.Mag)
+	return l, true
+}
+
+// outside steps from jde until f is positive, returning the first time
+// found with f positive.
+func outside(f func(float64) float64, jde, step float64) float64 {
+	for n := int(2 * localRange / math.Abs(step)); n > 0; n-- {
+		if jde += step; f(jde) > 0 {
+			break
+		}
+	}
+	return jde
+}
+
+// obscuration returns the fraction of the area of the solar disk covered
+// by the Moon.
+//
+// Argument k is the ratio of apparent diameters Moon / Sun, mag is the
+// eclipse magnitude.
+func obscuration(k, mag float64) float64 {
+	// distance between centers in units of the solar radius
+	d := 1 + k - 2*mag
+	switch {
+	case d >= 1+k:
+		return 0
+	case d <= math.Abs(1-k):
+		return math.Min(1, k*k)
+	}
+	k2, d2 := k*k, d*d
+	a := k2*math.Acos((d2+k2-1)/(2*d*k)) +
+		math.Acos((d2+1-k2)/(2*d)) -
+		.5*math.Sqrt((-d+k+1)*(d+k-1)*(d-k+1)*(d+k+1))
+	return a / math.Pi
+}
@@ -0,0 +1,354 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// PathPoint is a point on the path of a solar eclipse.
+//
+// Longitude follows the convention of the book, positive west.
+type PathPoint struct {
+	JDE float64
+	globe.Coord
+}
+
+// A Line is a sequence of path points at successive times.
+type Line []PathPoint
+
+// Path holds the curves bounding the shadow of a solar eclipse on the
+// Earth.
+//
+// Each curve is a list of lines.  A curve is broken into separate lines
+// where it leaves the Earth.  Curves that do not exist for the eclipse,
+// such as the umbral limits of a partial eclipse, are nil.
+type Path struct {
+	Central            []Line // central line
+	North, South       []Line // northern and southern limits of the umbra
+	PenNorth, PenSouth []Line // northern and southern limits of the penumbra
+}
+
+// project finds the geographic point on the ellipsoid corresponding to
+// point ξ, η in the fundamental plane, on the hemisphere facing the Moon.
+//
+// ok is false if the point is off the Earth.
+func (b *Besselian) project(ξ, η float64) (g globe.Coord, ok bool) {
+	e2 := globe.Earth76.Eccentricity()
+	e2 *= e2
+	sd, cd := b.D.Sincos()
+	ρ1 := math.Sqrt(1 - e2*cd*cd)
+	η1 := η / ρ1
+	sd1 := sd / ρ1
+	cd1 := math.Sqrt(1-e2) * cd / ρ1
+	B := 1 - ξ*ξ - η1*η1
+	if B < 0 {
+		return
+	}
+	ζ1 := math.Sqrt(B)
+	φ1 := math.Asin(η1*cd1 + ζ1*sd1)
+	H := math.Atan2(ξ, ζ1*cd1-η1*sd1)
+	g.Lat = unit.Angle(math.Atan(math.Tan(φ1) / math.Sqrt(1-e2)))
+	g.Lon = unit.Angle(math.Remainder(b.Mu.Rad()-H, 2*math.Pi))
+	return g, true
+}
+
+// central finds the point on the central line at time jde.
+func central(jde float64) (p PathPoint, ok bool) {
+	b := BesselianElements(jde)
+	p.JDE = jde
+	p.Coord, ok = b.project(b.X, b.Y)
+	return
+}
+
+// limit finds the point on the northern (side = 1) or southern (side = -1)
+// limit of the umbra (pen = false) or penumbra (pen = true) at time jde.
+func limit(jde float64, side float64, pen bool) (p PathPoint, ok bool) {
+	const dt = 1e-4 // days
+	b := BesselianElements(jde)
+	b0 := BesselianElements(jde - dt)
+	b1 := BesselianElements(jde + dt)
+	// first approximation ignores rotation of the Earth and radius of
+	// the shadow at the surface
+	vx, vy := b1.X-b0.X, b1.Y-b0.Y
+	r := b.L1
+	if !pen {
+		r = math.Abs(b.L2)
+	}
+	p.JDE = jde
+	g := &p.Coord
+	for i := 0; i < 5; i++ {
+		v := math.Hypot(vx, vy)
+		if *g, ok = b.project(b.X-side*r*vy/v, b.Y+side*r*vx/v); !ok {
+			return
+		}
+		s, c := globe.Earth76.ParallaxConstants(g.Lat, 0)
+		u0, v0, _, _ := b0.local(s, c, g.Lon)
+		u1, v1, _, _ := b1.local(s, c, g.Lon)
+		vx, vy = u1-u0, v1-v0
+		_, _, L1, L2 := b.local(s, c, g.Lon)
+		r = L1
+		if !pen {
+			r = math.Abs(L2)
+		}
+	}
+	return
+}
+
+// Path computes the path of the eclipse at intervals of step days.
+//
+// Step must be positive; the result is empty otherwise.  Umbral limits
+// and the central line are computed only for central eclipses.  The
+// accuracy is that of BesselianElements.
+func (e *SolarEclipse) Path(step float64) (p Path) {
+	if e.Type == None || !(step > 0) {
+		return
+	}
+	var c, n, s, pn, ps lines
+	for t := e.JMax - localRange; t <= e.JMax+localRange; t += step {
+		if e.Central {
+			c.add(central(t))
+			n.add(limit(t, 1, false))
+			s.add(limit(t, -1, false))
+		}
+		pn.add(limit(t, 1, true))
+		ps.add(limit(t, -1, true))
+	}
+	return Path{c.l, n.l, s.l, pn.l, ps.l}
+}
+
+// lines accumulates path points into lines, starting a new line after
+// each point that does not exist.
+type lines struct {
+	l   []Line
+	gap bool
+}
+
+func (ls *lines) add(p PathPoint, ok bool) {
+	if !ok {
+		ls.gap = true
+		return
+	}
+	if ls.l == nil || ls.gap {
+		ls.l = append(ls.l, nil)
+		ls.gap = false
+	}
+	i := len(ls.l) - 1
+	ls.l[i] = append(ls.l[i], p)
+}
+
+type geoJSON struct {
+	Type     string       `json:"type"`
+	Features []geoFeature `json:"features"`
+}
+
+type geoFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoGeometry            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// position returns a GeoJSON position, longitude positive east.
+func (p PathPoint) position() [2]float64 {
+	return [2]float64{-p.Lon.Deg(), p.Lat.Deg()}
+}
+
+// unwrap returns GeoJSON positions of the points of l, with longitudes
+// made continuous rather than reduced to the range -180 to 180.
+func unwrap(l Line) [][2]float64 {
+	u := make([][2]float64, len(l))
+	for i, p := range l {
+		u[i] = p.position()
+		if i > 0 {
+			u[i][0] = u[i-1][0] + math.Remainder(u[i][0]-u[i-1][0], 360)
+		}
+	}
+	return u
+}
+
+// band numbers the 360° intervals of continuous longitude, band 0 being
+// the range -180 to 180.
+func band(lon float64) float64 {
+	return math.Floor((lon + 180) / 360)
+}
+
+// split splits line l at the antimeridian, as RFC 7946 requires.
+//
+// The crossing point is interpolated and ends one part and starts the next.
+// Times are returned with the same structure as the positions.
+func split(l Line) (pos [][][2]float64, jde [][]float64) {
+	u := unwrap(l)
+	var p [][2]float64
+	var j []float64
+	k := band(u[0][0])
+	for i, x := range u {
+		if i > 0 {
+			if k1 := band(x[0]); k1 != k {
+				m := 360*math.Max(k, k1) - 180
+				f := (m - u[i-1][0]) / (x[0] - u[i-1][0])
+				lat := u[i-1][1] + f*(x[1]-u[i-1][1])
+				t := l[i-1].JDE + f*(l[i].JDE-l[i-1].JDE)
+				pos = append(pos, append(p, [2]float64{m - 360*k, lat}))
+				jde = append(jde, append(j, t))
+				p = [][2]float64{{m - 360*k1, lat}}
+				j = []float64{t}
+				k = k1
+			}
+		}
+		p = append(p, [2]float64{x[0] - 360*k, x[1]})
+		j = append(j, l[i].JDE)
+	}
+	return append(pos, p), append(jde, j)
+}
+
+// umbra returns rings bounding the path of the umbra, one for each time
+// interval where both the northern and southern limits exist.
+//
+// The rings are open, with longitudes continuous.
+func (p *Path) umbra() (rings [][][2]float64) {
+	for _, n := range p.North {
+		for _, s := range p.South {
+			// limits are computed at the same times, so points of the
+			// overlapping interval can be matched exactly.
+			t0 := math.Max(n[0].JDE, s[0].JDE)
+			t1 := math.Min(n[len(n)-1].JDE, s[len(s)-1].JDE)
+			var r Line
+			for _, pt := range n {
+				if pt.JDE >= t0 && pt.JDE <= t1 {
+					r = append(r, pt)
+				}
+			}
+			nn := len(r)
+			for i := len(s) - 1; i >= 0; i-- {
+				if s[i].JDE >= t0 && s[i].JDE <= t1 {
+					r = append(r, s[i])
+				}
+			}
+			if nn < 2 || len(r)-nn < 2 {
+				continue
+			}
+			rings = append(rings, unwrap(r))
+		}
+	}
+	return
+}
+
+// clip clips open ring r to the half plane of longitudes greater than
+// (side = 1) or less than (side = -1) m.
+func clip(r [][2]float64, m, side float64) (c [][2]float64) {
+	in := func(x [2]float64) bool { return side*(x[0]-m) >= 0 }
+	for i, x := range r {
+		y := r[(i+1)%len(r)]
+		if in(x) {
+			c = append(c, x)
+		}
+		if in(x) != in(y) {
+			f := (m - x[0]) / (y[0] - x[0])
+			c = append(c, [2]float64{m, x[1] + f*(y[1]-x[1])})
+		}
+	}
+	return
+}
+
+// polygons splits open ring r with continuous longitudes at the
+// antimeridian, returning closed, counterclockwise GeoJSON polygons.
+func polygons(r [][2]float64) (ps [][][][2]float64) {
+	k0, k1 := math.Inf(1), math.Inf(-1)
+	for _, x := range r {
+		k := band(x[0])
+		k0 = math.Min(k0, k)
+		k1 = math.Max(k1, k)
+	}
+	for k := k0; k <= k1; k++ {
+		c := r
+		if k0 < k1 {
+			c = clip(clip(r, 360*k-180, 1), 360*k+180, -1)
+		}
+		if len(c) < 3 {
+			continue
+		}
+		ring := make([][2]float64, len(c)+1)
+		var a float64 // twice the signed area
+		for i, x := range c {
+			y := c[(i+1)%len(c)]
+			a += x[0]*y[1] - y[0]*x[1]
+			ring[i] = [2]float64{x[0] - 360*k, x[1]}
+		}
+		ring[len(c)] = ring[0]
+		if a < 0 {
+			for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+				ring[i], ring[j] = ring[j], ring[i]
+			}
+		}
+		ps = append(ps, [][][2]float64{ring})
+	}
+	return
+}
+
+// GeoJSON encodes the path as a GeoJSON FeatureCollection.
+//
+// Each curve that exists is a MultiLineString feature with a "name"
+// property of "central", "north", "south", "penumbral north", or
+// "penumbral south", and a "jde" property holding the times of the points
+// with the same structure as the coordinates.  When both umbral limits
+// exist, the path of totality or annularity is given as well as a
+// MultiPolygon feature named "umbra", with a polygon for each interval
+// where both limits exist.
+//
+// Longitudes are positive east, as GeoJSON requires.  Lines and polygons
+// crossing the antimeridian are split there, as RFC 7946 recommends.
+// Lines of a single point are omitted.
+func (p *Path) GeoJSON() ([]byte, error) {
+	g := geoJSON{Type: "FeatureCollection", Features: []geoFeature{}}
+	for _, c := range []struct {
+		name string
+		ls   []Line
+	}{
+		{"central", p.Central},
+		{"north", p.North},
+		{"south", p.South},
+		{"penumbral north", p.PenNorth},
+		{"penumbral south", p.PenSouth},
+	} {
+		var pos [][][2]float64
+		var jde [][]float64
+		for _, l := range c.ls {
+			if len(l) < 2 {
+				continue
+			}
+			lp, lj := split(l)
+			pos = append(pos, lp...)
+			jde = append(jde, lj...)
+		}
+		if len(pos) == 0 {
+			continue
+		}
+		g.Features = append(g.Features, geoFeature{
+			Type:       "Feature",
+			Geometry:   geoGeometry{"MultiLineString", pos},
+			Properties: map[string]interface{}{"name": c.name, "jde": jde},
+		})
+	}
+	var ps [][][][2]float64
+	for _, r := range p.umbra() {
+		ps = append(ps, polygons(r)...)
+	}
+	if len(ps) > 0 {
+		g.Features = append(g.Features, geoFeature{
+			Type:       "Feature",
+			Geometry:   geoGeometry{"MultiPolygon", ps},
+			Properties: map[string]interface{}{"name": "umbra"},
+		})
+	}
+	return json.Marshal(g)
+}
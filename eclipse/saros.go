@@ -0,0 +1,182 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import "math"
+
+// Saros and Inex numbers are related to the lunation number k by
+//
+//	k = 223 I + 358 S + c
+//
+// where S is the Saros number, I the Inex number, and c a constant.
+// Members of a Saros series have consecutive Inex numbers and members
+// of an Inex series have consecutive Saros numbers.
+const (
+	// solar eclipse of 2017 August 21: k = 218, Saros 145, Inex 50
+	solarC = -62842
+	// lunar eclipse of 2000 January 21: k = .5, Saros 124, Inex 41,
+	// with k - .5 in place of k
+	lunarC = -53535
+
+	sarosMin = -20 // lowest Saros number returned
+	inv358   = 38  // 358 * 38 ≡ 1 (mod 223)
+	inexMin  = -300
+	inexMax  = 400
+)
+
+// sarosInex solves m = 223 i + 358 s with s in [sarosMin, sarosMin+223).
+func sarosInex(m int) (s, i int) {
+	s = (m*inv358 - sarosMin) % 223
+	if s < 0 {
+		s += 223
+	}
+	s += sarosMin
+	return s, (m - 358*s) / 223
+}
+
+// Saros returns the Saros series and Inex series numbers of the eclipse.
+func (e *SolarEclipse) Saros() (saros, inex int) {
+	return sarosInex(int(math.Floor(e.K+.5)) - solarC)
+}
+
+// Saros returns the Saros series and Inex series numbers of the eclipse.
+//
+// Saros numbers are those of van den Bergh as used by NASA.  Inex series
+// of lunar eclipses have no standard numbering; they are numbered here so
+// that the lunar eclipse of 2000 January 21 is in Inex series 41.
+func (e *LunarEclipse) Saros() (saros, inex int) {
+	return sarosInex(int(math.Floor(e.K)) - lunarC)
+}
+
+// SarosNext returns the next eclipse of the same Saros series.
+//
+// ok is false if the eclipse is the last of its series.
+func (e *SolarEclipse) SarosNext() (SolarEclipse, bool) { return SolarK(e.K + 223) }
+
+// SarosPrev returns the previous eclipse of the same Saros series.
+//
+// ok is false if the eclipse is the first of its series.
+func (e *SolarEclipse) SarosPrev() (SolarEclipse, bool) { return SolarK(e.K - 223) }
+
+// SarosNext returns the next eclipse of the same Saros series.
+//
+// ok is false if the eclipse is the last of its series.
+func (e *LunarEclipse) SarosNext() (LunarEclipse, bool) { return LunarK(e.K - .5 + 223) }
+
+// SarosPrev returns the previous eclipse of the same Saros series.
+//
+// ok is false if the eclipse is the first of its series.
+func (e *LunarEclipse) SarosPrev() (LunarEclipse, bool) { return LunarK(e.K - .5 - 223) }
+
+// member finds lunation k in Saros series s, as returned by series.
+//
+// n is 0 if k is not in the series.
+func member(k float64, s, c int, f func(float64) bool) (n, of int) {
+	ks := series(s, c, f)
+	for i, ki := range ks {
+		if ki == k {
+			return i + 1, len(ks)
+		}
+	}
+	return 0, len(ks)
+}
+
+// Member returns the position n of the eclipse within its Saros series,
+// counting from 1, and the number of eclipses in the series.
+//
+// The series is that of SolarSaros.  n is 0 for an isolated eclipse
+// outside of it.
+//
+// Each call computes the whole series, several hundred eclipse
+// computations.  To number the members of a series, use Series or SolarSaros
+// once and take positions in the result.
+func (e *SolarEclipse) Member() (n, of int) {
+	s, _ := e.Saros()
+	return member(math.Floor(e.K+.5), s, solarC, func(k float64) bool {
+		_, ok := SolarK(k)
+		return ok
+	})
+}
+
+// Member returns the position n of the eclipse within its Saros series,
+// counting from 1, and the number of eclipses in the series.
+//
+// The series is that of LunarSaros.  n is 0 for an isolated eclipse
+// outside of it.
+//
+// Each call computes the whole series, several hundred eclipse
+// computations.  To number the members of a series, use Series or LunarSaros
+// once and take positions in the result.
+func (e *LunarEclipse) Member() (n, of int) {
+	s, _ := e.Saros()
+	return member(math.Floor(e.K), s, lunarC, func(k float64) bool {
+		_, ok := LunarK(k)
+		return ok
+	})
+}
+
+// series returns lunations of Saros series s, where the series satisfies
+// k = 223 I + 358 s + c and f reports if there is an eclipse at a lunation.
+//
+// Far from the present the approximations of Solar and Lunar sometimes
+// report isolated eclipses at lunations of a series.  The longest run of
+// consecutive eclipses is taken as the series.
+func series(s, c int, f func(float64) bool) (ks []float64) {
+	var run []float64
+	for i := inexMin; i <= inexMax; i++ {
+		k := float64(223*i + 358*s + c)
+		if f(k) {
+			run = append(run, k)
+			continue
+		}
+		if len(run) > len(ks) {
+			ks = run
+		}
+		run = nil
+	}
+	if len(run) > len(ks) {
+		ks = run
+	}
+	return
+}
+
+// SolarSaros returns the eclipses of Saros series s in chronological order.
+func SolarSaros(s int) []SolarEclipse {
+	ks := series(s, solarC, func(k float64) bool {
+		_, ok := SolarK(k)
+		return ok
+	})
+	es := make([]SolarEclipse, len(ks))
+	for i, k := range ks {
+		es[i], _ = SolarK(k)
+	}
+	return es
+}
+
+// LunarSaros returns the eclipses of Saros series s in chronological order.
+func LunarSaros(s int) []LunarEclipse {
+	ks := series(s, lunarC, func(k float64) bool {
+		_, ok := LunarK(k)
+		return ok
+	})
+	es := make([]LunarEclipse, len(ks))
+	for i, k := range ks {
+		es[i], _ = LunarK(k)
+	}
+	return es
+}
+
+// Series returns the eclipses of the Saros series of the eclipse, in
+// chronological order, as SolarSaros.
+func (e *SolarEclipse) Series() []SolarEclipse {
+	s, _ := e.Saros()
+	return SolarSaros(s)
+}
+
+// Series returns the eclipses of the Saros series of the eclipse, in
+// chronological order, as LunarSaros.
+func (e *LunarEclipse) Series() []LunarEclipse {
+	s, _ := e.Saros()
+	return LunarSaros(s)
+}
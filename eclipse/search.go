@@ -0,0 +1,104 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// SolarEclipse holds the results of Solar for a single eclipse.
+//
+// Fields correspond to the return values of Solar.  Gamma is γ.
+type SolarEclipse struct {
+	K       float64 // lunation number of the New Moon, as in moonphase
+	Type    int     // Partial, Annular, AnnularTotal, or Total
+	Central bool
+	JMax    float64 // jde of maximum eclipse
+	Gamma   float64
+	U, P    float64 // umbral and penumbral radii
+	Mag     float64 // magnitude, for partial eclipses only
+}
+
+// LunarEclipse holds the results of Lunar for a single eclipse.
+//
+// Fields correspond to the return values of Lunar.  Gamma, Rho, and Sigma
+// are γ, ρ, and σ.
+type LunarEclipse struct {
+	K                               float64 // lunation number of the Full Moon, k + .5
+	Type                            int     // Penumbral, Umbral, or Total
+	JMax                            float64
+	Gamma, Rho, Sigma               float64
+	Mag                             float64
+	SdTotal, SdPartial, SdPenumbral unit.Time
+}
+
+// year returns a decimal year that snaps to lunation k.
+//
+// k is integer for New Moon, integer + .5 for Full Moon.
+func year(k float64) float64 {
+	return 2000 + k/12.3685
+}
+
+// lunation returns k for the first New Moon at or before jde.
+func lunation(jde float64) float64 {
+	// inverse of (49.1) p. 349 neglecting terms of higher order
+	return math.Floor((jde - 2451550.09766) / 29.530588861)
+}
+
+// SolarK returns the solar eclipse at lunation k, where k is an integer
+// as in moonphase.
+//
+// ok is false if there is no eclipse at the New Moon of lunation k.
+func SolarK(k float64) (e SolarEclipse, ok bool) {
+	e.K = k
+	e.Type, e.Central, e.JMax, e.Gamma, e.U, e.P, e.Mag = Solar(year(k))
+	return e, e.Type != None
+}
+
+// LunarK returns the lunar eclipse at the Full Moon following New Moon
+// of lunation k, where k is an integer as in moonphase.
+//
+// ok is false if there is no eclipse at that Full Moon.
+func LunarK(k float64) (e LunarEclipse, ok bool) {
+	e.K = k + .5
+	e.Type, e.JMax, e.Gamma, e.Rho, e.Sigma, e.Mag,
+		e.SdTotal, e.SdPartial, e.SdPenumbral = Lunar(year(k + .5))
+	return e, e.Type != None
+}
+
+// SolarEclipses returns all solar eclipses with time of maximum eclipse
+// in the interval [jde0, jde1), in chronological order.
+func SolarEclipses(jde0, jde1 float64) (s []SolarEclipse) {
+	for k := lunation(jde0) - 1; ; k++ {
+		e, ok := SolarK(k)
+		if !ok {
+			continue
+		}
+		if e.JMax >= jde1 {
+			return
+		}
+		if e.JMax >= jde0 {
+			s = append(s, e)
+		}
+	}
+}
+
+// LunarEclipses returns all lunar eclipses with time of maximum eclipse
+// in the interval [jde0, jde1), in chronological order.
+func LunarEclipses(jde0, jde1 float64) (l []LunarEclipse) {
+	for k := lunation(jde0) - 1; ; k++ {
+		e, ok := LunarK(k)
+		if !ok {
+			continue
+		}
+		if e.JMax >= jde1 {
+			return
+		}
+		if e.JMax >= jde0 {
+			l = append(l, e)
+		}
+	}
+}
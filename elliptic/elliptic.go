@@ -27,25 +27,23 @@ import (
 //
 // Results are right ascension and declination, α and δ in radians.
 func Position(p, earth *pp.V87Planet, jde float64) (α unit.RA, δ unit.Angle) {
+	α, δ, _ = PositionDistance(p, earth, jde)
+	return
+}
+
+// PositionDistance returns observed equatorial coordinates of a planet
+// at a given time, as Position, together with the true distance Δ in AU
+// of the planet from the Earth, as Distance.
+//
+// It evaluates the VSOP87 series once for both results.
+func PositionDistance(p, earth *pp.V87Planet, jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
 	L0, B0, R0 := earth.Position(jde)
-	L, B, R := p.Position(jde)
-	sB0, cB0 := B0.Sincos()
-	sL0, cL0 := L0.Sincos()
-	sB, cB := B.Sincos()
-	sL, cL := L.Sincos()
-	x := R*cB*cL - R0*cB0*cL0
-	y := R*cB*sL - R0*cB0*sL0
-	z := R*sB - R0*sB0
+	x, y, z := geocentric(p, L0, B0, R0, jde)
+	Δ = math.Sqrt(x*x + y*y + z*z) // (33.4) p. 224
 	{
-		Δ := math.Sqrt(x*x + y*y + z*z) // (33.4) p. 224
 		τ := base.LightTime(Δ)
 		// repeating with jde-τ
-		L, B, R = p.Position(jde - τ)
-		sB, cB = B.Sincos()
-		sL, cL = L.Sincos()
-		x = R*cB*cL - R0*cB0*cL0
-		y = R*cB*sL - R0*cB0*sL0
-		z = R*sB - R0*sB0
+		x, y, z = geocentric(p, L0, B0, R0, jde-τ)
 	}
 	λ := unit.Angle(math.Atan2(y, x))                // (33.1) p. 223
 	β := unit.Angle(math.Atan2(z, math.Hypot(x, y))) // (33.2) p. 223
@@ -54,11 +52,38 @@ func Position(p, earth *pp.V87Planet, jde float64) (α unit.RA, δ unit.Angle) {
 	Δψ, Δε := nutation.Nutation(jde)
 	λ += Δψ
 	sε, cε := (nutation.MeanObliquity(jde) + Δε).Sincos()
-	return coord.EclToEq(λ, β, sε, cε)
+	α, δ = coord.EclToEq(λ, β, sε, cε)
+	return
 	// Meeus gives a formula for elongation but doesn't spell out how to
 	// obtain term λ0 and doesn't give an example solution.
 }
 
+// geocentric returns ecliptic rectangular coordinates of planet p at jde
+// relative to the Earth at heliocentric position L0, B0, R0.
+func geocentric(p *pp.V87Planet, L0, B0 unit.Angle, R0, jde float64) (x, y, z float64) {
+	L, B, R := p.Position(jde)
+	sB0, cB0 := B0.Sincos()
+	sL0, cL0 := L0.Sincos()
+	sB, cB := B.Sincos()
+	sL, cL := L.Sincos()
+	// (33.1) p. 223
+	x = R*cB*cL - R0*cB0*cL0
+	y = R*cB*sL - R0*cB0*sL0
+	z = R*sB - R0*sB0
+	return
+}
+
+// Distance returns the true distance Δ in AU of a planet from the Earth
+// at a given time, not corrected for light time.
+//
+// Argument p must be a valid V87Planet object for the observed planet.
+// Argument earth must be a valid V87Planet object for Earth.
+func Distance(p, earth *pp.V87Planet, jde float64) float64 {
+	L0, B0, R0 := earth.Position(jde)
+	x, y, z := geocentric(p, L0, B0, R0, jde)
+	return math.Sqrt(x*x + y*y + z*z) // (33.4) p. 224
+}
+
 // Elements holds keplerian elements.
 type Elements struct {
 	Axis  float64    // Semimajor axis, a, in AU
@@ -69,6 +94,21 @@ type Elements struct {
 	TimeP float64    // Time of perihelion, T, as jde
 }
 
+// AnomalyDistance returns true anomaly and distance of a body with
+// Keplerian elements.
+//
+// Distance r is in AU.
+func (k *Elements) AnomalyDistance(jde float64) (ν unit.Angle, r float64) {
+	// (33.6) p. 227
+	n := base.K / k.Axis / math.Sqrt(k.Axis)
+	M := unit.Angle(n * (jde - k.TimeP))
+	E, err := kepler.Kepler2b(k.Ecc, M, 15)
+	if err != nil {
+		E = kepler.Kepler3(k.Ecc, M)
+	}
+	return kepler.True(E, k.Ecc), kepler.Radius(E, k.Ecc, k.Axis)
+}
+
 // Position returns observed equatorial coordinates of a body with Keplerian elements.
 //
 // Argument e must be a valid V87Planet object for Earth.
@@ -76,12 +116,27 @@ type Elements struct {
 // Results are right ascension and declination α and δ, and elongation ψ,
 // all in radians.
 func (k *Elements) Position(jde float64, e *pp.V87Planet) (α unit.RA, δ, ψ unit.Angle) {
-	// (33.6) p. 227
-	n := base.K / k.Axis / math.Sqrt(k.Axis)
+	return AstrometricJ2000(RectangularJ2000(k.Inc, k.ArgP, k.Node,
+		k.AnomalyDistance), jde, e)
+}
+
+// RectangularJ2000 is a utility function for computing heliocentric
+// rectangular coordinates.
+//
+// It is used internally and only exported so that it can be used from
+// multiple packages.
+//
+// Arguments i, ω, Ω orient the orbit and are referred to the ecliptic and
+// equinox of J2000.  Argument f returns true anomaly and distance in AU
+// for a jde.
+//
+// The result is a function returning J2000 equatorial rectangular
+// coordinates of the body, as needed by AstrometricJ2000.
+func RectangularJ2000(i, ω, Ω unit.Angle, f func(jde float64) (ν unit.Angle, r float64)) func(jde float64) (x, y, z float64) {
 	const sε = base.SOblJ2000
 	const cε = base.COblJ2000
-	sΩ, cΩ := k.Node.Sincos()
-	si, ci := k.Inc.Sincos()
+	sΩ, cΩ := Ω.Sincos()
+	si, ci := i.Sincos()
 	// (33.7) p. 228
 	F := cΩ
 	G := sΩ * cε
@@ -96,22 +151,14 @@ func (k *Elements) Position(jde float64, e *pp.V87Planet) (α unit.RA, δ, ψ un
 	a := math.Hypot(F, P)
 	b := math.Hypot(G, Q)
 	c := math.Hypot(H, R)
-
-	f := func(jde float64) (x, y, z float64) {
-		M := unit.Angle(n * (jde - k.TimeP))
-		E, err := kepler.Kepler2b(k.Ecc, M, 15)
-		if err != nil {
-			E = kepler.Kepler3(k.Ecc, M)
-		}
-		ν := kepler.True(E, k.Ecc)
-		r := kepler.Radius(E, k.Ecc, k.Axis)
+	return func(jde float64) (x, y, z float64) {
+		ν, r := f(jde)
 		// (33.9) p. 229
-		x = r * a * (A + k.ArgP + ν).Sin()
-		y = r * b * (B + k.ArgP + ν).Sin()
-		z = r * c * (C + k.ArgP + ν).Sin()
+		x = r * a * (A + ω + ν).Sin()
+		y = r * b * (B + ω + ν).Sin()
+		z = r * c * (C + ω + ν).Sin()
 		return
 	}
-	return AstrometricJ2000(f, jde, e)
 }
 
 // AstrometricJ2000 is a utility function for computing astrometric coordinates.
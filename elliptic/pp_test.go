@@ -36,6 +36,23 @@ func ExamplePosition() {
 	// δ = -18°53′16″.84
 }
 
+func ExampleDistance() {
+	// Example 33.a, p. 225.
+	earth, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	venus, err := pp.LoadPlanet(pp.Venus)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Δ = %.3f AU\n", elliptic.Distance(venus, earth, 2448976.5))
+	// Output:
+	// Δ = 0.911 AU
+}
+
 func ExampleElements_Position() {
 	// Example 33.b, p. 232.
 	earth, err := pp.LoadPlanet(pp.Earth)
@@ -0,0 +1,229 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Feast: Movable feasts of the Christian calendar.
+//
+// This package does not correspond to a chapter of the book.  It extends
+// package easter, which gives the date of Easter Sunday only, to the feasts
+// that are reckoned from Easter and to Advent Sunday, which is reckoned
+// from Christmas.
+//
+// Two computus variants are supported.  Western computes Easter with
+// easter.Gregorian for years after 1582 and with easter.Julian for earlier
+// years, as did the Western church.  Orthodox computes Easter (Pascha) with
+// easter.Julian in all years, as the Orthodox churches do.
+//
+// The two computus variants also have different sets of feasts.  Western
+// feasts include Septuagesima, Ash Wednesday, Corpus Christi, and Advent
+// Sunday.  Orthodox feasts include Clean Monday and All Saints, and
+// Orthodox Trinity Sunday is Pentecost.
+//
+// Results are given as JD at 0h of the day of the feast, and as month and
+// day in either the Gregorian or Julian calendar.
+package feast
+
+import (
+	"github.com/yanjunhui/meeus/calendar"
+	"github.com/yanjunhui/meeus/easter"
+	"github.com/yanjunhui/meeus/julian"
+)
+
+// Computus selects the rules for computing the date of Easter.
+type Computus int
+
+const (
+	Western  Computus = iota // Gregorian computus after 1582
+	Orthodox                 // Julian computus
+)
+
+// A Feast is a movable feast.
+//
+// Each computus has its own set of feasts, listed by Feasts.  Feasts
+// observed in both are the same constant, with offsets from Easter
+// appropriate to each computus.
+type Feast int
+
+const (
+	Septuagesima Feast = iota // Western
+	CleanMonday               // Orthodox, the start of Great Lent
+	AshWednesday              // Western, the start of Lent
+	PalmSunday
+	GoodFriday
+	Easter // Pascha in the Orthodox churches
+	Ascension
+	Pentecost
+	TrinitySunday // Western; for Orthodox, the same day as Pentecost
+	AllSaints     // Orthodox, the Sunday after Pentecost
+	CorpusChristi // Western
+	AdventSunday  // Western
+	nFeasts
+)
+
+var westernFeasts = []Feast{
+	Septuagesima,
+	AshWednesday,
+	PalmSunday,
+	GoodFriday,
+	Easter,
+	Ascension,
+	Pentecost,
+	TrinitySunday,
+	CorpusChristi,
+	AdventSunday,
+}
+
+var orthodoxFeasts = []Feast{
+	CleanMonday,
+	PalmSunday,
+	GoodFriday,
+	Easter,
+	Ascension,
+	Pentecost,
+	AllSaints,
+}
+
+// Feasts returns the feasts of computus c in chronological order.
+//
+// The Orthodox list omits TrinitySunday, which is Pentecost.
+func Feasts(c Computus) []Feast {
+	if c == Orthodox {
+		return append([]Feast(nil), orthodoxFeasts...)
+	}
+	return append([]Feast(nil), westernFeasts...)
+}
+
+var names = [nFeasts]string{
+	"Septuagesima",
+	"Clean Monday",
+	"Ash Wednesday",
+	"Palm Sunday",
+	"Good Friday",
+	"Easter",
+	"Ascension",
+	"Pentecost",
+	"Trinity Sunday",
+	"All Saints",
+	"Corpus Christi",
+	"Advent Sunday",
+}
+
+// String returns the English name of the feast.
+func (f Feast) String() string { return names[f] }
+
+// Name returns the name of the feast as used with computus c.
+//
+// It differs from String only for the Orthodox Easter, Pascha.
+func (f Feast) Name(c Computus) string {
+	if c == Orthodox && f == Easter {
+		return "Pascha"
+	}
+	return names[f]
+}
+
+// days after Easter of feasts reckoned from Easter, for each computus.
+// Feasts not observed are absent from the maps.
+var offset = [...]map[Feast]int{
+	Western: {
+		Septuagesima:  -63,
+		AshWednesday:  -46,
+		PalmSunday:    -7,
+		GoodFriday:    -2,
+		Easter:        0,
+		Ascension:     39,
+		Pentecost:     49,
+		TrinitySunday: 56,
+		CorpusChristi: 60,
+	},
+	Orthodox: {
+		CleanMonday:   -48,
+		PalmSunday:    -7,
+		GoodFriday:    -2,
+		Easter:        0,
+		Ascension:     39,
+		Pentecost:     49,
+		TrinitySunday: 49,
+		AllSaints:     56,
+	},
+}
+
+// EasterJD returns the JD of Easter Sunday of year y.
+func EasterJD(y int, c Computus) float64 {
+	if c == Western && y > 1582 {
+		m, d := easter.Gregorian(y)
+		return julian.CalendarGregorianToJD(y, m, float64(d))
+	}
+	m, d := easter.Julian(y)
+	return julian.CalendarJulianToJD(y, m, float64(d))
+}
+
+// adventJD returns the JD of Western Advent Sunday of year y, the fourth
+// Sunday before Christmas.
+func adventJD(y int) float64 {
+	// Christmas Eve is Gregorian if it falls on or after the reform.
+	jd := julian.CalendarGregorianToJD(y, 12, 24)
+	if jd < calendar.Papal.Reform {
+		jd = julian.CalendarJulianToJD(y, 12, 24)
+	}
+	// last Sunday on or before December 24, then back three weeks.
+	return jd - float64(julian.DayOfWeek(jd)) - 21
+}
+
+// JD returns the JD of feast f of year y.
+//
+// ok is false if feast f is not observed with computus c.
+func JD(y int, f Feast, c Computus) (jd float64, ok bool) {
+	if f == AdventSunday {
+		if c != Western {
+			return
+		}
+		return adventJD(y), true
+	}
+	o, ok := offset[c][f]
+	if !ok {
+		return
+	}
+	return EasterJD(y, c) + float64(o), true
+}
+
+// Gregorian returns month and day of feast f of year y in the
+// Gregorian calendar.
+//
+// For years before 1583 the Gregorian calendar is extended proleptically.
+// ok is false if feast f is not observed with computus c.
+func Gregorian(y int, f Feast, c Computus) (m, d int, ok bool) {
+	jd, ok := JD(y, f, c)
+	if !ok {
+		return
+	}
+	_, m, df := julian.JDToCalendarGregorian(jd)
+	return m, int(df), true
+}
+
+// Julian returns month and day of feast f of year y in the Julian
+// calendar.
+//
+// ok is false if feast f is not observed with computus c.
+func Julian(y int, f Feast, c Computus) (m, d int, ok bool) {
+	jd, ok := JD(y, f, c)
+	if !ok {
+		return
+	}
+	_, m, df := julian.JDToCalendarJulian(jd)
+	return m, int(df), true
+}
+
+// Table returns the JDs of the feasts of year y, in the order of
+// Feasts(c).
+func Table(y int, c Computus) []float64 {
+	fs := Feasts(c)
+	t := make([]float64, len(fs))
+	e := EasterJD(y, c)
+	for i, f := range fs {
+		if f == AdventSunday {
+			t[i] = adventJD(y)
+		} else {
+			t[i] = e + float64(offset[c][f])
+		}
+	}
+	return t
+}
@@ -0,0 +1,161 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package feast_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yanjunhui/meeus/easter"
+	"github.com/yanjunhui/meeus/feast"
+	"github.com/yanjunhui/meeus/julian"
+)
+
+func ExampleGregorian() {
+	for _, c := range []feast.Computus{feast.Western, feast.Orthodox} {
+		for _, f := range feast.Feasts(c) {
+			m, d, _ := feast.Gregorian(2024, f, c)
+			fmt.Printf("%-15s %-9s %2d\n", f.Name(c), time.Month(m), d)
+		}
+		fmt.Println()
+	}
+	// Output:
+	// Septuagesima    January   28
+	// Ash Wednesday   February  14
+	// Palm Sunday     March     24
+	// Good Friday     March     29
+	// Easter          March     31
+	// Ascension       May        9
+	// Pentecost       May       19
+	// Trinity Sunday  May       26
+	// Corpus Christi  May       30
+	// Advent Sunday   December   1
+	//
+	// Clean Monday    March     18
+	// Palm Sunday     April     28
+	// Good Friday     May        3
+	// Pascha          May        5
+	// Ascension       June      13
+	// Pentecost       June      23
+	// All Saints      June      30
+}
+
+func ExampleJulian() {
+	// Pascha 2024 in the Julian calendar.
+	m, d, _ := feast.Julian(2024, feast.Easter, feast.Orthodox)
+	fmt.Println(time.Month(m), d)
+	// Output:
+	// April 22
+}
+
+func TestEaster(t *testing.T) {
+	// Western Easter agrees with package easter, Julian calendar before
+	// the reform.
+	for _, y := range []int{1243, 1582, 1583, 1818, 1954, 2000, 2038} {
+		var m, d int
+		if y > 1582 {
+			m, d = easter.Gregorian(y)
+		} else {
+			m, d = easter.Julian(y)
+		}
+		jd, _ := feast.JD(y, feast.Easter, feast.Western)
+		if y > 1582 {
+			if gm, gd, _ := feast.Gregorian(y, feast.Easter, feast.Western); gm != m || gd != d {
+				t.Errorf("%d: got %d %d, want %d %d", y, gm, gd, m, d)
+			}
+		} else if gm, gd, _ := feast.Julian(y, feast.Easter, feast.Western); gm != m || gd != d {
+			t.Errorf("%d: got %d %d, want %d %d", y, gm, gd, m, d)
+		}
+		if julian.DayOfWeek(jd) != 0 {
+			t.Errorf("%d: Easter not on Sunday", y)
+		}
+	}
+}
+
+func TestOrthodox(t *testing.T) {
+	// Orthodox calendar of 2023, Gregorian dates: Pascha April 16.
+	for _, c := range []struct {
+		f    feast.Feast
+		m, d int
+	}{
+		{feast.CleanMonday, 2, 27},
+		{feast.Easter, 4, 16},
+		{feast.Ascension, 5, 25},
+		{feast.Pentecost, 6, 4},
+		{feast.TrinitySunday, 6, 4},
+		{feast.AllSaints, 6, 11},
+	} {
+		m, d, ok := feast.Gregorian(2023, c.f, feast.Orthodox)
+		if !ok || m != c.m || d != c.d {
+			t.Errorf("%v: got %d %d %t, want %d %d", c.f, m, d, ok, c.m, c.d)
+		}
+	}
+	for _, f := range []feast.Feast{feast.Septuagesima, feast.AshWednesday,
+		feast.CorpusChristi, feast.AdventSunday} {
+		if _, ok := feast.JD(2023, f, feast.Orthodox); ok {
+			t.Errorf("%v observed with Orthodox computus", f)
+		}
+	}
+	for _, f := range []feast.Feast{feast.CleanMonday, feast.AllSaints} {
+		if _, ok := feast.JD(2023, f, feast.Western); ok {
+			t.Errorf("%v observed with Western computus", f)
+		}
+	}
+}
+
+func TestAdvent1582(t *testing.T) {
+	// Christmas 1582 was already Gregorian in the Western church.
+	// Advent Sunday was Gregorian November 28.
+	m, d, _ := feast.Gregorian(1582, feast.AdventSunday, feast.Western)
+	if m != 11 || d != 28 {
+		t.Errorf("got %d %d, want 11 28", m, d)
+	}
+	// The year before, Julian December 3.
+	m, d, _ = feast.Julian(1581, feast.AdventSunday, feast.Western)
+	if m != 12 || d != 3 {
+		t.Errorf("got %d %d, want 12 3", m, d)
+	}
+}
+
+func TestTable(t *testing.T) {
+	for _, c := range []feast.Computus{feast.Western, feast.Orthodox} {
+		for y := 1900; y < 2100; y++ {
+			tb := feast.Table(y, c)
+			for i, f := range feast.Feasts(c) {
+				if jd, ok := feast.JD(y, f, c); !ok || tb[i] != jd {
+					t.Fatalf("%d %v: table %.1f, JD %.1f", y, f, tb[i], jd)
+				}
+				if i > 0 && tb[i] <= tb[i-1] {
+					t.Fatalf("%d %v: not in chronological order", y, f)
+				}
+			}
+		}
+		for y := 1583; y < 2100; y++ {
+			jd, ok := feast.JD(y, feast.AdventSunday, c)
+			if !ok {
+				continue
+			}
+			if julian.DayOfWeek(jd) != 0 {
+				t.Fatalf("%d: Advent Sunday not on Sunday", y)
+			}
+			m, d, _ := feast.Gregorian(y, feast.AdventSunday, c)
+			if !(m == 11 && d >= 27 || m == 12 && d <= 3) {
+				t.Fatalf("%d: Advent Sunday %d %d", y, m, d)
+			}
+		}
+	}
+}
+
+func TestFeastsCopy(t *testing.T) {
+	// Changing the result of Feasts does not change later results.
+	fs := feast.Feasts(feast.Western)
+	fs[0] = feast.AdventSunday
+	if feast.Feasts(feast.Western)[0] != feast.Septuagesima {
+		t.Fatal("Feasts returned shared slice")
+	}
+	if jd, ok := feast.JD(2023, feast.AdventSunday, feast.Orthodox); ok || jd != 0 {
+		t.Fatal("Orthodox Advent Sunday", jd, ok)
+	}
+}
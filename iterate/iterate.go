@@ -75,3 +75,30 @@ func BinaryRoot(f RootFunc, lower, upper float64) float64 {
 	}
 	return mid
 }
+
+// MinFunc is a convenience type definition.
+type MinFunc func(float64) float64
+
+// GoldenMin finds a minimum between given bounds by golden section search.
+//
+// Inputs are a function on x, the bounds on x, and the tolerance on x of
+// the result.  The function must have a single minimum between the given
+// bounds, otherwise the result is not meaningful.
+func GoldenMin(f MinFunc, lower, upper, tol float64) float64 {
+	const r = .6180339887498949 // (√5 - 1) / 2
+	c := upper - r*(upper-lower)
+	d := lower + r*(upper-lower)
+	fc, fd := f(c), f(d)
+	for upper-lower > tol {
+		if fc < fd {
+			upper, d, fd = d, c, fc
+			c = upper - r*(upper-lower)
+			fc = f(c)
+		} else {
+			lower, c, fc = c, d, fd
+			d = lower + r*(upper-lower)
+			fd = f(d)
+		}
+	}
+	return (lower + upper) / 2
+}
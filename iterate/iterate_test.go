@@ -96,3 +96,11 @@ func ExampleBinaryRoot() {
 	// Output:
 	// 0.46924987845473876
 }
+
+func ExampleGoldenMin() {
+	// The minimum of cos x between 3 and 4 is at π.
+	x := iterate.GoldenMin(math.Cos, 3, 4, 1e-9)
+	fmt.Printf("%.6f\n", x)
+	// Output:
+	// 3.141593
+}
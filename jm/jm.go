@@ -17,6 +17,12 @@
 // that they are not direct inverses:  JulianToGregorian returns the day number
 // of the day of the Gregorian year, but GregorianToJulian wants the Gregorian
 // month and day of month as input.
+//
+// Not in the book, but related, are functions for the astronomical Persian
+// (Solar Hijri) calendar.  The Persian year begins on the day of the March
+// equinox as determined at the meridian of Tehran, so these functions
+// take a VSOP87 Earth object as used by the chapter 27 function
+// solstice.March2.
 package jm
 
 import (
@@ -0,0 +1,124 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package jm
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/eqtime"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/solstice"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Tehran is the longitude of the meridian used by the astronomical Persian
+// calendar.  It is measured positively westward, as in package globe.
+var Tehran = unit.NewAngle('-', 51, 25, 0)
+
+// PersianNewYear returns the JD of Nowruz, the first day of Farvardin,
+// of year y of the astronomical Persian calendar.
+//
+// The year begins on the day when the March equinox occurs before true
+// (apparent) noon at the meridian of Tehran.  If the equinox occurs after
+// noon, the year begins on the following day.
+//
+// Result is the JD at 0h of the Gregorian calendar day of Nowruz, in
+// the same sense as julian.CalendarGregorianToJD with an integer day.
+//
+// Parameter e must be a V87Planet object representing Earth, obtained with
+// the package planetposition.
+func PersianNewYear(y int, e *pp.V87Planet) float64 {
+	jde := solstice.March2(y+621, e)
+	// local apparent solar time of the equinox, as a JD
+	t := jde - deltat.Interp10A(jde).Day() - Tehran.Rad()/(2*math.Pi) +
+		eqtime.E(jde, e).Time().Day()
+	d := math.Floor(t+.5) - .5
+	if t-d >= .5 {
+		d++
+	}
+	return d
+}
+
+// PersianToJD converts a date of the astronomical Persian calendar to
+// Julian day.
+//
+// The first six months have 31 days, the next five have 30, and Esfand has
+// 29 days, or 30 in a leap year.  Result is the JD at 0h of the day.
+//
+// Parameter e must be a V87Planet object representing Earth, obtained with
+// the package planetposition.
+func PersianToJD(y, m, d int, e *pp.V87Planet) float64 {
+	return PersianNewYear(y, e) + float64(persianDaysBefore(m)+d-1)
+}
+
+// persianDaysBefore returns the number of days in the year before month m.
+func persianDaysBefore(m int) int {
+	if m <= 7 {
+		return 31 * (m - 1)
+	}
+	return 30*(m-1) + 6
+}
+
+// JDToPersian returns the date of the astronomical Persian calendar
+// for the given jd.
+//
+// Parameter e must be a V87Planet object representing Earth, obtained with
+// the package planetposition.
+func JDToPersian(jd float64, e *pp.V87Planet) (y, m, d int) {
+	gy, _, _ := julian.JDToCalendar(jd)
+	y = gy - 621
+	ny := PersianNewYear(y, e)
+	// Nowruz is close to March 20 so the Persian year can begin at most
+	// one Gregorian year earlier.
+	if math.Floor(jd+.5) < ny+.5 {
+		y--
+		ny = PersianNewYear(y, e)
+	}
+	n := int(math.Floor(jd+.5) - (ny + .5)) // day number within year, 0 based
+	if n < 186 {
+		m = n/31 + 1
+		d = n%31 + 1
+	} else {
+		n -= 186
+		m = n/30 + 7
+		d = n%30 + 1
+	}
+	return
+}
+
+// PersianLeapYear returns true if year y of the astronomical Persian
+// calendar has 366 days.
+//
+// Parameter e must be a V87Planet object representing Earth, obtained with
+// the package planetposition.
+func PersianLeapYear(y int, e *pp.V87Planet) bool {
+	return PersianNewYear(y+1, e)-PersianNewYear(y, e) > 365
+}
+
+// A PMonth specifies a month of the Persian Calendar (Farvardin = 1, ...).
+//
+// This type is modeled after the Month type of the time package in the
+// Go standard library.
+type PMonth int
+
+// Source: http://en.wikipedia.org/wiki/Iranian_calendars.
+var pmonths = [12]string{
+	"Farvardin",
+	"Ordibehesht",
+	"Khordad",
+	"Tir",
+	"Mordad",
+	"Shahrivar",
+	"Mehr",
+	"Aban",
+	"Azar",
+	"Dey",
+	"Bahman",
+	"Esfand",
+}
+
+// String returns the Romanization of the month ("Farvardin", "Ordibehesht", ...).
+func (m PMonth) String() string { return pmonths[m-1] }
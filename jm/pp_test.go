@@ -0,0 +1,89 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+//go:build !nopp
+// +build !nopp
+
+package jm_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yanjunhui/meeus/jm"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+)
+
+func ExamplePersianNewYear() {
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for y := 1399; y <= 1404; y++ {
+		_, m, d := julian.JDToCalendar(jm.PersianNewYear(y, e))
+		fmt.Println(y, ":", time.Month(m), d)
+	}
+	// Output:
+	// 1399 : March 20
+	// 1400 : March 21
+	// 1401 : March 21
+	// 1402 : March 21
+	// 1403 : March 20
+	// 1404 : March 21
+}
+
+func ExampleJDToPersian() {
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	y, m, d := jm.JDToPersian(julian.CalendarGregorianToJD(2024, 10, 18), e)
+	fmt.Println(d, jm.PMonth(m), y)
+	// Output:
+	// 27 Mehr 1403
+}
+
+func TestPersian(t *testing.T) {
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tp := range []struct {
+		y, m, d    int
+		gy, gm, gd int
+	}{
+		{1399, 12, 30, 2021, 3, 20},
+		{1400, 1, 1, 2021, 3, 21},
+		{1402, 12, 29, 2024, 3, 19},
+		{1403, 7, 1, 2024, 9, 22},
+		{1403, 12, 30, 2025, 3, 20},
+	} {
+		jd := julian.CalendarGregorianToJD(tp.gy, tp.gm, float64(tp.gd))
+		if got := jm.PersianToJD(tp.y, tp.m, tp.d, e); got != jd {
+			t.Errorf("PersianToJD(%d, %d, %d) = %.1f, want %.1f",
+				tp.y, tp.m, tp.d, got, jd)
+		}
+		y, m, d := jm.JDToPersian(jd, e)
+		if y != tp.y || m != tp.m || d != tp.d {
+			t.Errorf("JDToPersian(%.1f) = %d %d %d, want %d %d %d",
+				jd, y, m, d, tp.y, tp.m, tp.d)
+		}
+	}
+	for _, tp := range []struct {
+		y    int
+		leap bool
+	}{
+		{1399, true},
+		{1400, false},
+		{1402, false},
+		{1403, true},
+	} {
+		if jm.PersianLeapYear(tp.y, e) != tp.leap {
+			t.Errorf("PersianLeapYear(%d) != %t", tp.y, tp.leap)
+		}
+	}
+}
@@ -67,46 +67,45 @@ func LeapYearGregorian(y int) bool {
 // JDToCalendar returns the calendar date for the given jd.
 //
 // Note that this function returns a date in either the Julian or Gregorian
-// Calendar, as appropriate.
+// Calendar, as appropriate.  The Gregorian calendar is taken to start on
+// 1582 October 15.  For other dates of adoption see type Historical of
+// package calendar.
 func JDToCalendar(jd float64) (year, month int, day float64) {
 	zf, f := math.Modf(jd + .5)
 	z := int64(zf)
 	a := z
-	if z >= 2299151 {
+	if z >= 2299161 {
 		α := base.FloorDiv64(z*100-186721625, 3652425)
 		a = z + 1 + α - base.FloorDiv64(α, 4)
 	}
-	b := a + 1524
-	c := base.FloorDiv64(b*100-12210, 36525)
-	d := base.FloorDiv64(36525*c, 100)
-	e := int(base.FloorDiv64((b-d)*1e4, 306001))
-	// compute return values
-	day = float64(int(b-d)-base.FloorDiv(306001*e, 1e4)) + f
-	switch e {
-	default:
-		month = e - 1
-	case 14, 15:
-		month = e - 13
-	}
-	switch month {
-	default:
-		year = int(c) - 4716
-	case 1, 2:
-		year = int(c) - 4715
-	}
-	return
+	return ymd(a, f)
 }
 
-// jdToCalendarGregorian returns the Gregorian calendar date for the given jd.
+// JDToCalendarGregorian returns the Gregorian calendar date for the given jd.
 //
 // Note that it returns a Gregorian date even for dates before the start of
 // the Gregorian calendar.  The function is useful when working with Go
 // time.Time values because they are always based on the Gregorian calendar.
-func jdToCalendarGregorian(jd float64) (year, month int, day float64) {
+func JDToCalendarGregorian(jd float64) (year, month int, day float64) {
 	zf, f := math.Modf(jd + .5)
 	z := int64(zf)
 	α := base.FloorDiv64(z*100-186721625, 3652425)
 	a := z + 1 + α - base.FloorDiv64(α, 4)
+	return ymd(a, f)
+}
+
+// JDToCalendarJulian returns the Julian calendar date for the given jd.
+//
+// Note that it returns a Julian date even for dates after the start of
+// the Gregorian calendar.
+func JDToCalendarJulian(jd float64) (year, month int, day float64) {
+	zf, f := math.Modf(jd + .5)
+	return ymd(int64(zf), f)
+}
+
+// ymd completes the computation of JDToCalendar and similar functions
+// from the intermediate value A and the day fraction f.
+func ymd(a int64, f float64) (year, month int, day float64) {
 	b := a + 1524
 	c := base.FloorDiv64(b*100-12210, 36525)
 	d := base.FloorDiv64(36525*c, 100)
@@ -131,11 +130,29 @@ func jdToCalendarGregorian(jd float64) (year, month int, day float64) {
 // JDToTime takes a JD and returns a Go time.Time value.
 func JDToTime(jd float64) time.Time {
 	// time.Time is always Gregorian
-	y, m, d := jdToCalendarGregorian(jd)
+	y, m, d := JDToCalendarGregorian(jd)
 	t := time.Date(y, time.Month(m), 0, 0, 0, 0, 0, time.UTC)
 	return t.Add(time.Duration(d * 24 * float64(time.Hour)))
 }
 
+// JDToTimeIn takes a JD and returns a Go time.Time value in location loc.
+//
+// The JD is taken as UT.  The result is the same instant as JDToTime,
+// but with the date and clock time of the location.
+func JDToTimeIn(jd float64, loc *time.Location) time.Time {
+	return JDToTime(jd).In(loc)
+}
+
+// LocalDayToJD returns the JD of the start of a local calendar day.
+//
+// Argument y, m, d is a Gregorian date in location loc.  The result is
+// the JD (UT) of local midnight beginning that day.  Events with a JD in
+// the range [LocalDayToJD(y, m, d, loc), LocalDayToJD(y, m, d+1, loc))
+// fall on that local date.
+func LocalDayToJD(y, m, d int, loc *time.Location) float64 {
+	return TimeToJD(time.Date(y, time.Month(m), d, 0, 0, 0, 0, loc))
+}
+
 // TimeToJD takes a Go time.Time and returns a JD as float64.
 //
 // Any time zone offset in the time.Time is ignored and the time is
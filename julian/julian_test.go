@@ -133,6 +133,8 @@ func TestYMD(t *testing.T) {
 		d    float64
 	}{
 		{1842713, 333, 1, 27.5},
+		{2299159.5, 1582, 10, 4},
+		{2299160.5, 1582, 10, 15},
 		{1507900.13, -584, 5, 28.63},
 	} {
 		y, m, d := julian.JDToCalendar(tp.jd)
@@ -143,6 +145,22 @@ func TestYMD(t *testing.T) {
 	}
 }
 
+func ExampleJDToTimeIn() {
+	// Sputnik launch, in Moscow time.
+	msk := time.FixedZone("MSK", 3*3600)
+	fmt.Println(julian.JDToTimeIn(2436116.31, msk).Format(time.RFC3339))
+	// Output:
+	// 1957-10-04T22:26:24+03:00
+}
+
+func ExampleLocalDayToJD() {
+	// In New York the date 2000 January 1 begins at 5h UT.
+	est := time.FixedZone("EST", -5*3600)
+	fmt.Printf("%.4f\n", julian.LocalDayToJD(2000, 1, 1, est))
+	// Output:
+	// 2451544.7083
+}
+
 func ExampleDayOfWeek() {
 	// Example 7.e, p. 65.
 	fmt.Println(time.Weekday(julian.DayOfWeek(2434923.5)))
@@ -181,3 +199,39 @@ func TestDOYToCal(t *testing.T) {
 		}
 	}
 }
+
+func TestJDToCalendarJulian(t *testing.T) {
+	for _, tp := range []struct {
+		jd   float64
+		y, m int
+		d    float64
+	}{
+		{2299160.5, 1582, 10, 5}, // Julian date of Gregorian 1582 October 15
+		{2451544.5, 1999, 12, 19},
+		{1842713, 333, 1, 27.5},
+	} {
+		y, m, d := julian.JDToCalendarJulian(tp.jd)
+		if y != tp.y || m != tp.m || math.Abs(d-tp.d) > .01 {
+			t.Logf("%#v", tp)
+			t.Fatal("JDToCalendarJulian", y, m, d)
+		}
+	}
+}
+
+func TestJDToCalendarGregorian(t *testing.T) {
+	for _, tp := range []struct {
+		jd   float64
+		y, m int
+		d    float64
+	}{
+		{2299150.5, 1582, 10, 5}, // proleptic, JD of Julian 1582 September 25
+		{2451544.5, 2000, 1, 1},
+		{2436116.31, 1957, 10, 4.81},
+	} {
+		y, m, d := julian.JDToCalendarGregorian(tp.jd)
+		if y != tp.y || m != tp.m || math.Abs(d-tp.d) > .01 {
+			t.Logf("%#v", tp)
+			t.Fatal("JDToCalendarGregorian", y, m, d)
+		}
+	}
+}
@@ -6,6 +6,9 @@
 // Also see functions Illuminated and Limb in package base.  The function
 // for computing illuminated fraction given a phase angle (48.1) is
 // base.Illuminated.  Formula (48.5) is implemented as base.Limb.
+//
+// Function StateAt brings these together with the age and distance of the
+// Moon for a given instant.
 package moonillum
 
 import (
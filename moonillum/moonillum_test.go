@@ -11,6 +11,7 @@ import (
 	"github.com/yanjunhui/meeus/base"
 	"github.com/yanjunhui/meeus/julian"
 	"github.com/yanjunhui/meeus/moonillum"
+	"github.com/yanjunhui/meeus/moonphase"
 	"github.com/yanjunhui/meeus/moonposition"
 	"github.com/yanjunhui/meeus/solar"
 	"github.com/yanjunhui/meeus/unit"
@@ -75,3 +76,39 @@ func ExamplePhaseAngle3() {
 	// i = 68.88
 	// k = 0.6801
 }
+
+func ExampleStateAt() {
+	// Example 48.a, p. 347.
+	s := moonillum.StateAt(julian.CalendarGregorianToJD(1992, 4, 12))
+	fmt.Printf("age: %.2f days\n", s.Age)
+	fmt.Println(s.Name)
+	fmt.Printf("ψ = %.2f°\n", s.Elongation.Deg())
+	fmt.Printf("i = %.2f°\n", s.PhaseAngle.Deg())
+	fmt.Printf("k = %.4f\n", s.Illuminated)
+	fmt.Printf("χ = %.1f°\n", s.Limb.Deg())
+	fmt.Printf("Δ = %.0f km\n", s.Distance)
+	// Output:
+	// age: 8.79 days
+	// Waxing Gibbous
+	// ψ = 110.79°
+	// i = 69.08°
+	// k = 0.6786
+	// χ = 285.0°
+	// Δ = 368410 km
+}
+
+func TestStateAtName(t *testing.T) {
+	// Names through a lunation appear in order.
+	nm := moonphase.PhaseK(0)
+	last := moonillum.NewMoon
+	for jde := nm.JDE + .01; jde < moonphase.PhaseK(1).JDE; jde += .1 {
+		s := moonillum.StateAt(jde)
+		if s.Name != last && s.Name != (last+1)%8 {
+			t.Fatalf("%.2f: %v follows %v", jde, s.Name, last)
+		}
+		last = s.Name
+	}
+	if last != moonillum.NewMoon {
+		t.Fatal("lunation ends with", last)
+	}
+}
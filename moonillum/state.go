@@ -0,0 +1,96 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package moonillum
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/moonphase"
+	"github.com/yanjunhui/meeus/moonposition"
+	"github.com/yanjunhui/meeus/nutation"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// PhaseName names the phase of the Moon as commonly described.
+type PhaseName int
+
+const (
+	NewMoon PhaseName = iota
+	WaxingCrescent
+	FirstQuarter
+	WaxingGibbous
+	FullMoon
+	WaningGibbous
+	LastQuarter
+	WaningCrescent
+)
+
+var phaseNames = [...]string{
+	"New Moon",
+	"Waxing Crescent",
+	"First Quarter",
+	"Waxing Gibbous",
+	"Full Moon",
+	"Waning Gibbous",
+	"Last Quarter",
+	"Waning Crescent",
+}
+
+// String returns the name, such as "Waxing Gibbous".
+func (n PhaseName) String() string { return phaseNames[n] }
+
+// State describes the Moon as seen from the center of the Earth at an
+// instant.
+type State struct {
+	Age         float64    // days since the previous New Moon
+	Name        PhaseName  // common name of the phase
+	Elongation  unit.Angle // geocentric elongation from the Sun, ψ
+	PhaseAngle  unit.Angle // phase angle, i
+	Illuminated float64    // illuminated fraction of the disk, k
+	Limb        unit.Angle // position angle of the bright limb, χ
+	Distance    float64    // distance between centers of Earth and Moon, in km
+}
+
+// StateAt returns the State of the Moon at jde.
+//
+// The Moon position is computed with moonposition.Position and the Sun
+// position with the low precision functions of package solar.
+//
+// The phase name is from the excess of the longitude of the Moon over that
+// of the Sun.  Names of the principal phases are given within 6° of 0°,
+// 90°, 180°, and 270°, or roughly within half a day of the phase.
+func StateAt(jde float64) (s State) {
+	λ, β, Δ := moonposition.Position(jde)
+	Δψ, Δε := nutation.Nutation(jde)
+	λ += Δψ
+	sε, cε := (nutation.MeanObliquity(jde) + Δε).Sincos()
+	α, δ := coord.EclToEq(λ, β, sε, cε)
+	T := base.J2000Century(jde)
+	λ0 := solar.ApparentLongitude(T)
+	R := solar.Radius(T) * base.AU
+	α0, δ0 := solar.ApparentEquatorial(jde)
+	cψ := cψEcl(λ, β, λ0)
+	s.Elongation = unit.Angle(math.Acos(cψ))
+	s.PhaseAngle = pa(Δ, R, cψ)
+	s.Illuminated = base.Illuminated(s.PhaseAngle)
+	s.Limb = base.Limb(α, δ, α0, δ0)
+	s.Distance = Δ
+	s.Name = phaseName((λ - λ0).Mod1().Deg())
+	// age from the New Moon preceding jde
+	nm := moonphase.PhaseK(math.Floor(moonphase.Previous(jde).K))
+	s.Age = jde - nm.JDE
+	return
+}
+
+// phaseName returns the name for excess of longitude d in degrees.
+func phaseName(d float64) PhaseName {
+	q := math.Floor(d/90 + .5) // nearest principal phase
+	if math.Abs(d-q*90) < 6 {
+		return PhaseName(int(q) % 4 * 2)
+	}
+	return PhaseName(int(d/90)*2 + 1)
+}
@@ -0,0 +1,119 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package moonphase
+
+import "math"
+
+// Phase identifies a principal phase of the Moon.
+type Phase int
+
+const (
+	NewMoon Phase = iota
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+var phaseNames = [...]string{"New Moon", "First Quarter", "Full Moon", "Last Quarter"}
+
+// String returns the name of the phase, such as "Full Moon".
+func (ph Phase) String() string { return phaseNames[ph] }
+
+// Event is a principal phase of the Moon.
+//
+// K is the value of k of (49.2), an integer for New Moon and increasing by
+// .25 for each following phase.  JDE is the time of the phase.
+type Event struct {
+	Phase Phase
+	K     float64
+	JDE   float64
+}
+
+// Lunation numbers of different numbering systems for k = 0, the New Moon
+// of 2000 January 6.
+const (
+	BrownLunation0   = 953
+	IslamicLunation0 = 17038
+)
+
+// Lunation returns the lunation number of Meeus, the integer part of k.
+//
+// Lunation 0 begins with the New Moon of 2000 January 6.
+func (e Event) Lunation() int { return int(math.Floor(e.K)) }
+
+// Brown returns the Brown lunation number.
+//
+// Brown lunation 1 began with the New Moon of 1923 January 17.
+func (e Event) Brown() int { return e.Lunation() + BrownLunation0 }
+
+// Islamic returns the Islamic lunation number, the count of months of the
+// Islamic calendar.
+//
+// Islamic lunation 1 is the month Muharram of the year 1 A.H.
+func (e Event) Islamic() int { return e.Lunation() + IslamicLunation0 }
+
+// PhaseK returns the phase for a value of k of (49.2).
+//
+// k must be an integer for New Moon, or an integer plus .25, .5, or .75
+// for First Quarter, Full Moon, or Last Quarter.
+func PhaseK(k float64) Event {
+	m := newMpK(k)
+	var jde float64
+	ph := Phase(math.Floor((k-math.Floor(k))*4 + .5))
+	switch ph {
+	case NewMoon:
+		jde = mean(m.T) + m.nfc(&nc) + m.a()
+	case FirstQuarter:
+		jde = mean(m.T) + m.flc() + m.w() + m.a()
+	case FullMoon:
+		jde = mean(m.T) + m.nfc(&fc) + m.a()
+	default:
+		jde = mean(m.T) + m.flc() - m.w() + m.a()
+	}
+	return Event{ph, k, jde}
+}
+
+// meanK returns k, in steps of .25, of the mean phase nearest jde.
+func meanK(jde float64) float64 {
+	return math.Floor((jde-2451550.09766)/29.530588861*4+.5) / 4
+}
+
+// Next returns the first principal phase after jde.
+func Next(jde float64) Event {
+	// the true phase is within a day of the mean phase
+	k := meanK(jde) - .25
+	for {
+		if e := PhaseK(k); e.JDE > jde {
+			return e
+		}
+		k += .25
+	}
+}
+
+// Previous returns the last principal phase at or before jde.
+func Previous(jde float64) Event {
+	k := meanK(jde) + .25
+	for {
+		if e := PhaseK(k); e.JDE <= jde {
+			return e
+		}
+		k -= .25
+	}
+}
+
+// Phases returns all principal phases in the interval [jde0, jde1), in
+// chronological order.
+func Phases(jde0, jde1 float64) (es []Event) {
+	if jde1 <= jde0 {
+		return
+	}
+	e := Next(jde0)
+	if p := Previous(jde0); p.JDE == jde0 {
+		e = p
+	}
+	for ; e.JDE < jde1; e = PhaseK(e.K + .25) {
+		es = append(es, e)
+	}
+	return
+}
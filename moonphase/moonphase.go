@@ -6,8 +6,11 @@ package moonphase
 
 import (
 	"math"
+	"time"
 
 	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/julian"
 )
 
 const ck = 1 / 1236.85
@@ -92,6 +95,21 @@ func Last(year float64) float64 {
 	return mean(m.T) + m.flc() - m.w() + m.a()
 }
 
+// DecimalYear returns the decimal year of t, suitable as the year argument
+// of the functions of this package.
+func DecimalYear(t time.Time) float64 {
+	return base.JDEToJulianYear(julian.TimeToJD(t))
+}
+
+// TimeIn converts a jde returned by the functions of this package to a
+// Go time.Time value in location loc.
+//
+// ΔT is applied to convert the jde to UT.  The local date of the phase is
+// then simply the date of the result, which may differ from the UT date.
+func TimeIn(jde float64, loc *time.Location) time.Time {
+	return julian.JDToTimeIn(jde-deltat.Interp10A(jde).Day(), loc)
+}
+
 type mp struct {
 	k, T           float64
 	E, M, Mʹ, F, Ω float64
@@ -101,7 +119,12 @@ type mp struct {
 const p = math.Pi / 180
 
 func newMp(y, q float64) *mp {
-	m := &mp{k: snap(y, q)}
+	return newMpK(snap(y, q))
+}
+
+// newMpK returns an mp for a specific k, as in (49.2).
+func newMpK(k float64) *mp {
+	m := &mp{k: k}
 	m.T = m.k * ck // (49.3) p. 350
 	m.E = base.Horner(m.T, 1, -.002516, -.0000074)
 	m.M = base.Horner(m.T, 2.5534*p, 29.1053567*p/ck,
@@ -5,7 +5,11 @@ package moonphase_test
 
 import (
 	"fmt"
+	"math"
+	"testing"
+	"time"
 
+	"github.com/yanjunhui/meeus/julian"
 	"github.com/yanjunhui/meeus/moonphase"
 )
 
@@ -36,3 +40,58 @@ func ExampleLast() {
 	// Output:
 	// JDE = 2467636.49186
 }
+
+func ExampleTimeIn() {
+	// Full Moon nearest 2024 January 1, in Tokyo and in Honolulu.
+	jde := moonphase.Full(moonphase.DecimalYear(
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	jst := time.FixedZone("JST", 9*3600)
+	hst := time.FixedZone("HST", -10*3600)
+	fmt.Println(moonphase.TimeIn(jde, jst).Format("Jan 2 15:04 MST"))
+	fmt.Println(moonphase.TimeIn(jde, hst).Format("Jan 2 15:04 MST"))
+	// Output:
+	// Dec 27 09:33 JST
+	// Dec 26 14:33 HST
+}
+
+func ExamplePhases() {
+	// Phases of the Moon in 2000 January, with lunation numbers.
+	jde0 := julian.CalendarGregorianToJD(2000, 1, 1)
+	jde1 := julian.CalendarGregorianToJD(2000, 2, 1)
+	for _, e := range moonphase.Phases(jde0, jde1) {
+		fmt.Printf("%-13s %.5f  Meeus %d  Brown %d  Islamic %d\n",
+			e.Phase, e.JDE, e.Lunation(), e.Brown(), e.Islamic())
+	}
+	// Output:
+	// New Moon      2451550.26026  Meeus 0  Brown 953  Islamic 17038
+	// First Quarter 2451558.06609  Meeus 0  Brown 953  Islamic 17038
+	// Full Moon     2451564.69547  Meeus 0  Brown 953  Islamic 17038
+	// Last Quarter  2451571.83184  Meeus 0  Brown 953  Islamic 17038
+}
+
+func TestPhaseK(t *testing.T) {
+	// PhaseK agrees with New and Last, Examples 49.a and 49.b.
+	if e := moonphase.PhaseK(-283); e.Phase != moonphase.NewMoon ||
+		math.Abs(e.JDE-moonphase.New(1977.13)) > 1e-9 {
+		t.Fatal(e)
+	}
+	if e := moonphase.PhaseK(544.75); e.Phase != moonphase.LastQuarter ||
+		math.Abs(e.JDE-moonphase.Last(2044.04)) > 1e-9 {
+		t.Fatal(e)
+	}
+}
+
+func TestNextPrevious(t *testing.T) {
+	jde := julian.CalendarGregorianToJD(1977, 2, 1)
+	for i := 0; i < 200; i++ {
+		n := moonphase.Next(jde)
+		p := moonphase.Previous(n.JDE)
+		if p != n {
+			t.Fatal(n, p)
+		}
+		if p = moonphase.Previous(n.JDE - 1e-6); p.K != n.K-.25 {
+			t.Fatal(n, p)
+		}
+		jde = n.JDE
+	}
+}
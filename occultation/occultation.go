@@ -0,0 +1,243 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Occultation: Lunar occultations of stars and planets.
+//
+// This package does not correspond to a chapter of the book.  It combines
+// the lunar position of package moonposition with parallax.Topocentric and
+// the topocentric semidiameter of package semidiameter to find times when
+// the Moon hides a star or planet for an observer at a given location.
+//
+// Times are found by searching for contacts of the target with the limb
+// of the Moon at intervals of a few minutes.  Grazing occultations lasting
+// less than the search interval may be missed.  The lunar position of
+// moonposition limits the accuracy of contact times to about half a
+// minute.  The lunar limb is taken as smooth.
+package occultation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yanjunhui/meeus/angle"
+	"github.com/yanjunhui/meeus/apparent"
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/elliptic"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/iterate"
+	"github.com/yanjunhui/meeus/moonposition"
+	"github.com/yanjunhui/meeus/nutation"
+	"github.com/yanjunhui/meeus/parallax"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/semidiameter"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Target is a function returning the apparent geocentric equatorial
+// coordinates of an occulted body at time jde, and its distance in AU.
+//
+// Distance 0 indicates a body at infinite distance, such as a star.
+type Target func(jde float64) (α unit.RA, δ unit.Angle, Δ float64)
+
+// Star returns a Target for a star.
+//
+// α, δ are the catalog position of the star, for equinox and epoch J2000.
+// mα, mδ are the annual proper motion of the star.
+func Star(α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle) Target {
+	return func(jde float64) (unit.RA, unit.Angle, float64) {
+		eq := &coord.Equatorial{RA: α, Dec: δ}
+		apparent.Position(eq, eq, 2000, base.JDEToJulianYear(jde), mα, mδ)
+		return eq.RA, eq.Dec, 0
+	}
+}
+
+// Planet returns a Target for planet pl, where e is the VSOP87 Earth.
+//
+// The target is the center of the planet's disk.
+func Planet(e, pl *pp.V87Planet) Target {
+	return func(jde float64) (unit.RA, unit.Angle, float64) {
+		return elliptic.PositionDistance(pl, e, jde)
+	}
+}
+
+// Contact holds circumstances of a disappearance or reappearance.
+type Contact struct {
+	JDE float64 // dynamical time
+	JD  float64 // universal time
+	// position angle of the target on the limb of the Moon, measured
+	// from north through east
+	PA unit.Angle
+	// cusp angle, the angle along the limb from the nearer cusp,
+	// positive on the dark limb and negative on the bright limb
+	Cusp   unit.Angle
+	Alt    unit.Angle // topocentric altitude of the Moon, without refraction
+	SunAlt unit.Angle // altitude of the Sun
+}
+
+// Occultation holds the contacts of a single occultation.
+type Occultation struct {
+	Target                      int // index of the occulted target
+	Disappearance, Reappearance Contact
+}
+
+// observer holds quantities used through a search.
+type observer struct {
+	p    globe.Coord
+	s, c float64 // parallax constants
+}
+
+// moon holds positions of the Moon at a time.
+type moon struct {
+	jde, jd float64    // dynamical and universal time
+	αg      unit.RA    // geocentric right ascension
+	δg      unit.Angle // geocentric declination
+	α       unit.RA    // topocentric right ascension
+	δ       unit.Angle // topocentric declination
+	s       unit.Angle // topocentric semidiameter
+}
+
+// moon computes positions of the Moon at jde.
+func (o *observer) moon(jde float64) (m moon) {
+	m.jde = jde
+	m.jd = jde - deltat.Interp10A(jde).Day()
+	λ, β, Δ := moonposition.Position(jde)
+	Δψ, Δε := nutation.Nutation(jde)
+	ε := nutation.MeanObliquity(jde) + Δε
+	sε, cε := ε.Sincos()
+	m.αg, m.δg = coord.EclToEq(λ+Δψ, β, sε, cε)
+	Δ /= base.AU
+	// parallax.Topocentric uses its time argument only for sidereal
+	// time, so UT is passed.
+	m.α, m.δ = parallax.Topocentric(m.αg, m.δg, Δ, o.s, o.c, o.p.Lon, m.jd)
+	H := unit.HourAngle(sidereal.Apparent(m.jd).Rad() - o.p.Lon.Rad() - m.αg.Rad())
+	m.s = unit.Angle(math.Asin(semidiameter.MoonTopocentric(Δ, m.δg, H, o.s, o.c)))
+	return
+}
+
+// body holds a position of a target, as returned by a Target.
+type body struct {
+	α unit.RA
+	δ unit.Angle
+	Δ float64
+}
+
+// at evaluates target t at jde.
+func at(t Target, jde float64) (b body) {
+	b.α, b.δ, b.Δ = t(jde)
+	return
+}
+
+// topo returns the topocentric position of target position b at the time
+// of m.
+func (o *observer) topo(m *moon, b body) (unit.RA, unit.Angle) {
+	if b.Δ > 0 {
+		return parallax.Topocentric(b.α, b.δ, b.Δ, o.s, o.c, o.p.Lon, m.jd)
+	}
+	return b.α, b.δ
+}
+
+// f returns the distance of target position b from the limb of the Moon,
+// negative when occulted.
+func (o *observer) f(m *moon, b body) float64 {
+	α, δ := o.topo(m, b)
+	return (angle.Sep(unit.Angle(m.α), m.δ, unit.Angle(α), δ) - m.s).Rad()
+}
+
+// geocentric returns the geocentric separation of the Moon and target
+// position b.
+func geocentric(m *moon, b body) unit.Angle {
+	return angle.Sep(unit.Angle(m.αg), m.δg, unit.Angle(b.α), b.δ)
+}
+
+func (o *observer) contact(jde float64, t Target) (c Contact) {
+	m := o.moon(jde)
+	α, δ := o.topo(&m, at(t, jde))
+	c.JDE = jde
+	c.JD = m.jd
+	// base.Limb computes the position angle of one point from another.
+	c.PA = base.Limb(m.α, m.δ, α, δ)
+	αs, δs := solar.ApparentEquatorial(jde)
+	χ := base.Limb(m.α, m.δ, αs, δs)
+	d := math.Remainder((c.PA - χ).Rad(), 2*math.Pi)
+	c.Cusp = unit.Angle(math.Abs(d) - math.Pi/2)
+	st := sidereal.Apparent(m.jd)
+	_, c.Alt = coord.EqToHz(m.α, m.δ, o.p.Lat, o.p.Lon, st)
+	_, c.SunAlt = coord.EqToHz(αs, δs, o.p.Lat, o.p.Lon, st)
+	return
+}
+
+// search parameters
+const (
+	near     = 1.6 * math.Pi / 180 // geocentric separation for fine search
+	rate     = 18 * math.Pi / 180  // maximum relative motion, radians/day
+	fineStep = 1. / 720            // 2 minutes
+)
+
+// Occultations finds occultations of targets ts by the Moon for an
+// observer at p with height h in meters above the ellipsoid.
+//
+// The position of the Moon is computed once for each step of the search
+// and used for all targets, and each target is evaluated once per step,
+// so searching for many targets at once is much faster than searching for
+// each in turn.  The Target field of each
+// Occultation is the index of the occulted target in ts.
+//
+// Occultations with disappearance in the interval [jde0, jde1) are
+// returned in chronological order of disappearance.  Occultations are
+// returned regardless of the altitudes of the Moon and Sun; check the Alt
+// and SunAlt fields of the contacts to see if an occultation is
+// observable.
+func Occultations(p globe.Coord, h float64, ts []Target, jde0, jde1 float64) (os []Occultation) {
+	o := &observer{p: p}
+	o.s, o.c = globe.Earth76.ParallaxConstants(p.Lat, h)
+	// f0 holds f of targets near the Moon at the previous step
+	f0 := make([]float64, len(ts))
+	ok := make([]bool, len(ts))
+	// a disappearance before jde1 is found at the step following it
+	for jde := jde0; jde < jde1+fineStep; {
+		m := o.moon(jde)
+		dMin := math.Inf(1)
+		for i, t := range ts {
+			b := at(t, jde)
+			d := geocentric(&m, b).Rad()
+			dMin = math.Min(dMin, d)
+			if d > near {
+				ok[i] = false
+				continue
+			}
+			f1 := o.f(&m, b)
+			if ok[i] && f0[i] > 0 && f1 <= 0 {
+				f := func(jde float64) float64 {
+					m := o.moon(jde)
+					return o.f(&m, at(t, jde))
+				}
+				if d := iterate.BinaryRoot(f, jde-fineStep, jde); d < jde1 {
+					// reappearance may be after jde1
+					r := d + fineStep
+					for ; f(r) <= 0; r += fineStep {
+					}
+					os = append(os, Occultation{
+						Target:        i,
+						Disappearance: o.contact(d, t),
+						Reappearance:  o.contact(iterate.BinaryRoot(f, r-fineStep, r), t),
+					})
+				}
+			}
+			f0[i], ok[i] = f1, true
+		}
+		// skip ahead while the Moon is far from all targets
+		if dMin > near {
+			jde += math.Max((dMin-near)/rate, fineStep)
+		} else {
+			jde += fineStep
+		}
+	}
+	sort.Slice(os, func(i, j int) bool {
+		return os[i].Disappearance.JDE < os[j].Disappearance.JDE
+	})
+	return
+}
@@ -0,0 +1,124 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package occultation_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/occultation"
+	"github.com/yanjunhui/meeus/sexa"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Aldebaran and an observer in Washington.
+var (
+	aldebaran = occultation.Star(
+		unit.NewRA(4, 35, 55.239),
+		unit.NewAngle(' ', 16, 30, 33.49),
+		unit.HourAngleFromSec(.004412),
+		unit.AngleFromSec(-.18894))
+	washington = globe.Coord{
+		Lat: unit.NewAngle(' ', 38, 54, 0),
+		Lon: unit.NewAngle(' ', 77, 2, 0),
+	}
+)
+
+func ExampleOccultations() {
+	// Occultation of Aldebaran on 2016 October 19, seen from Washington.
+	jde := julian.CalendarGregorianToJD(2016, 10, 18)
+	ts := []occultation.Target{aldebaran}
+	for _, o := range occultation.Occultations(washington, 0, ts, jde, jde+2) {
+		for _, c := range []struct {
+			name string
+			occultation.Contact
+		}{
+			{"Disappearance", o.Disappearance},
+			{"Reappearance", o.Reappearance},
+		} {
+			y, m, d := julian.JDToCalendar(c.JD)
+			fmt.Printf("%s %d-%02d-%02d %.0d UT\n", c.name, y, m, int(d),
+				sexa.FmtTime(unit.TimeFromDay(math.Mod(c.JD+.5, 1))))
+			fmt.Printf("  PA %.0f°  cusp %+.0f°  Moon alt %.0f°  Sun alt %.0f°\n",
+				c.PA.Deg(), c.Cusp.Deg(), c.Alt.Deg(), c.SunAlt.Deg())
+		}
+	}
+	// Output:
+	// Disappearance 2016-10-19 5ʰ37ᵐ14ˢ UT
+	//   PA 49°  cusp -51°  Moon alt 53°  Sun alt -60°
+	// Reappearance 2016-10-19 6ʰ44ᵐ24ˢ UT
+	//   PA 282°  cusp +76°  Moon alt 63°  Sun alt -52°
+}
+
+func TestYear(t *testing.T) {
+	// Aldebaran was occulted every month of 2016 somewhere on Earth.
+	// From Washington only some of these occur, lasting no more than
+	// about an hour and a half.
+	jde := julian.CalendarGregorianToJD(2016, 1, 1)
+	os := occultation.Occultations(washington, 0,
+		[]occultation.Target{aldebaran}, jde, jde+366)
+	if len(os) == 0 {
+		t.Fatal("no occultations")
+	}
+	for _, o := range os {
+		d := o.Reappearance.JDE - o.Disappearance.JDE
+		if d <= 0 || d > .07 {
+			t.Fatal("duration", d*24, "hours")
+		}
+		// the Moon moves eastward, so the star disappears at the east limb
+		if pa := o.Disappearance.PA.Deg(); pa <= 0 || pa >= 180 {
+			t.Fatal("disappearance PA", pa)
+		}
+		if pa := o.Reappearance.PA.Deg(); pa <= 180 || pa >= 360 {
+			t.Fatal("reappearance PA", pa)
+		}
+	}
+}
+
+func TestTargets(t *testing.T) {
+	// Searching several targets at once finds the occultations of
+	// searching each in turn.  Both Aldebaran and Regulus were occulted
+	// as seen from Washington in 2017.
+	ts := []occultation.Target{
+		aldebaran,
+		occultation.Star( // Regulus
+			unit.NewRA(10, 8, 22.311),
+			unit.NewAngle(' ', 11, 58, 1.95),
+			unit.HourAngleFromSec(-.01696),
+			unit.AngleFromSec(.00559)),
+	}
+	jde := julian.CalendarGregorianToJD(2017, 1, 1)
+	all := occultation.Occultations(washington, 0, ts, jde, jde+366)
+	var n int
+	for i, tg := range ts {
+		for _, o := range occultation.Occultations(washington, 0,
+			[]occultation.Target{tg}, jde, jde+366) {
+			found := false
+			for _, a := range all {
+				// contacts are found from different steps of the search
+				if a.Target == i &&
+					math.Abs(a.Disappearance.JDE-o.Disappearance.JDE) < 1e-6 &&
+					math.Abs(a.Reappearance.JDE-o.Reappearance.JDE) < 1e-6 {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatal("target", i, "occultation not found at",
+					o.Disappearance.JDE)
+			}
+			n++
+		}
+	}
+	if n == 0 || n != len(all) {
+		t.Fatal("found", len(all), "together,", n, "separately")
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Disappearance.JDE < all[i-1].Disappearance.JDE {
+			t.Fatal("not in chronological order")
+		}
+	}
+}
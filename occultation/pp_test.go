@@ -0,0 +1,40 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+//go:build !nopp
+// +build !nopp
+
+package occultation_test
+
+import (
+	"testing"
+
+	"github.com/yanjunhui/meeus/elliptic"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/occultation"
+	pp "github.com/yanjunhui/meeus/planetposition"
+)
+
+func TestPlanet(t *testing.T) {
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := pp.LoadPlanet(pp.Venus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jde := julian.CalendarGregorianToJD(2023, 11, 9)
+	α, δ, Δ := occultation.Planet(e, v)(jde)
+	αe, δe := elliptic.Position(v, e, jde)
+	if α != αe || δ != δe {
+		t.Fatal("position differs from elliptic.Position")
+	}
+	if Δ != elliptic.Distance(v, e, jde) {
+		t.Fatal("distance differs from elliptic.Distance")
+	}
+	// Venus ranges from about .26 to 1.74 AU from the Earth
+	if Δ < .26 || Δ > 1.74 {
+		t.Fatal("distance", Δ)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Prayer: Islamic prayer times and Jewish zmanim.
+//
+// This package does not correspond to a chapter of the book.  It builds on
+// packages rise and solar to compute times of day defined by the altitude
+// of the Sun.
+//
+// Islamic prayer times are computed by SalatTimes.  Fajr and Isha are
+// defined by a depression of the Sun below the horizon, which differs
+// between calculation methods.  Asr is defined by the length of the shadow
+// of an object relative to its length.  At high latitudes the Sun may not
+// reach the depression for Fajr or Isha, and a fallback rule is then needed.
+//
+// Jewish zmanim are computed by ZmanimTimes.  Most are reckoned in
+// sha'ot zmaniyot, seasonal hours of one twelfth of the day, where the day
+// is counted either from sunrise to sunset (GRA) or from 72 minutes before
+// sunrise to 72 minutes after sunset (MGA).
+//
+// As with package rise, results are UT times in seconds of day.  Positions
+// of the Sun are computed with the low precision solar.ApparentEquatorial.
+package prayer
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/rise"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// day holds values for a day needed by rise.Times.
+type day struct {
+	pos globe.Coord
+	ΔT  unit.Time
+	Th0 unit.Time
+	α   []unit.RA
+	δ   []unit.Angle
+}
+
+func newDay(yr, mon, dy int, pos globe.Coord) *day {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(dy))
+	d := &day{
+		pos: pos,
+		ΔT:  deltat.Interp10A(jd),
+		Th0: sidereal.Apparent0UT(jd),
+		α:   make([]unit.RA, 3),
+		δ:   make([]unit.Angle, 3),
+	}
+	for i := range d.α {
+		d.α[i], d.δ[i] = solar.ApparentEquatorial(jd + float64(i-1))
+	}
+	return d
+}
+
+// times returns morning and evening times the Sun is at altitude h.
+func (d *day) times(h unit.Angle) (am, pm unit.Time, err error) {
+	am, _, pm, err = rise.Times(d.pos, d.ΔT, h, d.Th0, d.α, d.δ)
+	return
+}
+
+func (d *day) transit() unit.Time {
+	t, _ := rise.Transit(d.pos, d.ΔT, d.Th0, d.α)
+	return t
+}
+
+// HighLat selects a rule for Fajr and Isha, or for other times defined by
+// a depression of the Sun, at high latitudes.
+//
+// Where the Sun does not reach the depression, or where the time computed
+// from it is further from sunrise or sunset than the rule allows, the time
+// is instead taken as a portion of the night before sunrise or after sunset.
+// The night is the time from sunset to sunrise.
+type HighLat int
+
+const (
+	// No fallback.  A depression not reached is reported as an error.
+	HighLatNone HighLat = iota
+	// Portion of the night is one half.
+	MiddleOfNight
+	// Portion of the night is one seventh.
+	OneSeventh
+	// Portion of the night is the depression angle divided by 60°.
+	AngleBased
+)
+
+// portion returns the portion of the night for depression a.
+func (r HighLat) portion(a unit.Angle) float64 {
+	switch r {
+	case MiddleOfNight:
+		return .5
+	case OneSeventh:
+		return 1. / 7
+	case AngleBased:
+		return a.Deg() / 60
+	}
+	return math.Inf(1)
+}
+
+// twilight returns times of morning and evening twilight at depression a,
+// applying rule r.  Sunrise and sunset sr, ss must be valid.
+func (d *day) twilight(a unit.Angle, r HighLat, sr, ss unit.Time) (am, pm unit.Time, err error) {
+	am, pm, err = d.times(-a)
+	if r == HighLatNone {
+		return
+	}
+	night := (sr - ss).Mod1()
+	max := night.Mul(r.portion(a))
+	if err != nil || (sr-am).Mod1() > max {
+		am = (sr - max).Mod1()
+	}
+	if err != nil || (pm-ss).Mod1() > max {
+		pm = (ss + max).Mod1()
+	}
+	return am, pm, nil
+}
+
+// Method is a convention for computing Fajr and Isha.
+//
+// Fajr and Isha are depressions of the Sun below the horizon.  If
+// IshaInterval is non-zero, Isha is instead that time after Maghrib.
+type Method struct {
+	Name         string
+	Fajr, Isha   unit.Angle
+	IshaInterval unit.Time
+}
+
+// Common calculation methods.
+var (
+	MWL = Method{Name: "Muslim World League",
+		Fajr: unit.AngleFromDeg(18), Isha: unit.AngleFromDeg(17)}
+	ISNA = Method{Name: "Islamic Society of North America",
+		Fajr: unit.AngleFromDeg(15), Isha: unit.AngleFromDeg(15)}
+	Egypt = Method{Name: "Egyptian General Authority of Survey",
+		Fajr: unit.AngleFromDeg(19.5), Isha: unit.AngleFromDeg(17.5)}
+	Karachi = Method{Name: "University of Islamic Sciences, Karachi",
+		Fajr: unit.AngleFromDeg(18), Isha: unit.AngleFromDeg(18)}
+	UmmAlQura = Method{Name: "Umm al-Qura University, Makkah",
+		Fajr: unit.AngleFromDeg(18.5), IshaInterval: unit.TimeFromMin(90)}
+)
+
+// Asr selects the shadow ratio defining the time of Asr.
+type Asr int
+
+const (
+	Standard Asr = 1 // Shafi'i, Maliki, Hanbali: shadow equals object length
+	Hanafi   Asr = 2 // shadow twice object length
+)
+
+// Salat holds UT times of the daily prayers, with sunrise.
+type Salat struct {
+	Fajr, Sunrise, Dhuhr, Asr, Maghrib, Isha unit.Time
+}
+
+// SalatTimes computes Islamic prayer times for a day of interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	m is the calculation method for Fajr and Isha.
+//	asr selects the shadow ratio for Asr.
+//	r is the rule for high latitudes.
+//
+// Dhuhr is taken at solar transit and Maghrib at sunset.  No precautionary
+// margins are added.
+//
+// Err is rise.ErrorCircumpolar if there is no sunrise and sunset on the
+// day, or if the Sun does not reach the altitude of Fajr, Asr, or Isha and
+// the high latitude rule gives no fallback.  On error the returned Salat
+// is zero.
+func SalatTimes(yr, mon, day int, pos globe.Coord, m Method, asr Asr, r HighLat) (s Salat, err error) {
+	d := newDay(yr, mon, day, pos)
+	if s.Sunrise, s.Maghrib, err = d.times(rise.Stdh0Solar); err != nil {
+		return Salat{}, err
+	}
+	s.Dhuhr = d.transit()
+	// Asr: the altitude where the shadow is the shadow at noon plus asr
+	// times the object length.
+	δ := d.δ[1]
+	hAsr := unit.Angle(math.Atan(1 / (float64(asr) + math.Abs((pos.Lat - δ).Tan()))))
+	if _, s.Asr, err = d.times(hAsr); err != nil {
+		return Salat{}, err
+	}
+	if s.Fajr, _, err = d.twilight(m.Fajr, r, s.Sunrise, s.Maghrib); err != nil {
+		return Salat{}, err
+	}
+	if m.IshaInterval != 0 {
+		s.Isha = (s.Maghrib + m.IshaInterval).Mod1()
+		return
+	}
+	if _, s.Isha, err = d.twilight(m.Isha, r, s.Sunrise, s.Maghrib); err != nil {
+		return Salat{}, err
+	}
+	return
+}
+
+// Zmanim conventions for reckoning the day for sha'ot zmaniyot.
+type Convention int
+
+const (
+	GRA Convention = iota // day from sunrise to sunset
+	MGA                   // day from 72 minutes before sunrise to 72 minutes after sunset
+)
+
+// Depressions of the Sun for zmanim.
+var (
+	AlotDepression       = unit.AngleFromDeg(16.1)
+	MisheyakirDepression = unit.AngleFromDeg(11.5)
+	TzeitDepression      = unit.AngleFromDeg(8.5)
+)
+
+// Zmanim holds UT times of Jewish zmanim.
+type Zmanim struct {
+	AlotHashachar unit.Time // dawn, Sun at AlotDepression
+	Misheyakir    unit.Time // Sun at MisheyakirDepression
+	Sunrise       unit.Time // netz hachamah
+	SofZmanShma   unit.Time // end of 3rd seasonal hour
+	SofZmanTfila  unit.Time // end of 4th seasonal hour
+	Chatzot       unit.Time // solar transit
+	MinchaGedola  unit.Time // 6½ seasonal hours
+	MinchaKetana  unit.Time // 9½ seasonal hours
+	PlagHamincha  unit.Time // 10¾ seasonal hours
+	Sunset        unit.Time // shkiah
+	Tzeit         unit.Time // nightfall, Sun at TzeitDepression
+	ShaahZmanit   unit.Time // length of the seasonal hour
+}
+
+// ZmanimTimes computes Jewish zmanim for a day of interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	c selects the day used for seasonal hours.
+//	r is the rule for high latitudes, applied to alot hashachar,
+//	misheyakir, and tzeit.
+//
+// Sof zman shma, sof zman tfila, and the mincha times are reckoned in
+// seasonal hours from the start of the day of convention c.  Chatzot is
+// solar transit under both conventions.
+//
+// Err is rise.ErrorCircumpolar if there is no sunrise and sunset on the
+// day, or if the Sun does not reach the depression of alot hashachar,
+// misheyakir, or tzeit and the high latitude rule gives no fallback.
+// On error the returned Zmanim is zero.
+func ZmanimTimes(yr, mon, day int, pos globe.Coord, c Convention, r HighLat) (z Zmanim, err error) {
+	d := newDay(yr, mon, day, pos)
+	if z.Sunrise, z.Sunset, err = d.times(rise.Stdh0Solar); err != nil {
+		return Zmanim{}, err
+	}
+	z.Chatzot = d.transit()
+	start, end := z.Sunrise, z.Sunset
+	if c == MGA {
+		start -= unit.TimeFromMin(72)
+		end += unit.TimeFromMin(72)
+	}
+	z.ShaahZmanit = (end - start).Mod1().Div(12)
+	hour := func(h float64) unit.Time { return (start + z.ShaahZmanit.Mul(h)).Mod1() }
+	z.SofZmanShma = hour(3)
+	z.SofZmanTfila = hour(4)
+	z.MinchaGedola = hour(6.5)
+	z.MinchaKetana = hour(9.5)
+	z.PlagHamincha = hour(10.75)
+	if z.AlotHashachar, _, err = d.twilight(AlotDepression, r, z.Sunrise, z.Sunset); err != nil {
+		return Zmanim{}, err
+	}
+	if z.Misheyakir, _, err = d.twilight(MisheyakirDepression, r, z.Sunrise, z.Sunset); err != nil {
+		return Zmanim{}, err
+	}
+	if _, z.Tzeit, err = d.twilight(TzeitDepression, r, z.Sunrise, z.Sunset); err != nil {
+		return Zmanim{}, err
+	}
+	return
+}
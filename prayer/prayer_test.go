@@ -0,0 +1,133 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package prayer_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/prayer"
+	"github.com/yanjunhui/meeus/rise"
+	"github.com/yanjunhui/meeus/sexa"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+func ExampleSalatTimes() {
+	// Makkah on 2024 March 20, Umm al-Qura method.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 39, 49, 34),
+		Lat: unit.NewAngle(' ', 21, 25, 21),
+	}
+	s, err := prayer.SalatTimes(2024, 3, 20, p, prayer.UmmAlQura,
+		prayer.Standard, prayer.HighLatNone)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Fajr:    %02s\n", sexa.FmtTime(s.Fajr))
+	fmt.Printf("Sunrise: %02s\n", sexa.FmtTime(s.Sunrise))
+	fmt.Printf("Dhuhr:   %02s\n", sexa.FmtTime(s.Dhuhr))
+	fmt.Printf("Asr:     %02s\n", sexa.FmtTime(s.Asr))
+	fmt.Printf("Maghrib: %02s\n", sexa.FmtTime(s.Maghrib))
+	fmt.Printf("Isha:    %02s\n", sexa.FmtTime(s.Isha))
+	// Output:
+	// Fajr:     02ʰ08ᵐ26ˢ
+	// Sunrise:  03ʰ24ᵐ32ˢ
+	// Dhuhr:    09ʰ28ᵐ03ˢ
+	// Asr:      12ʰ53ᵐ16ˢ
+	// Maghrib:  15ʰ31ᵐ52ˢ
+	// Isha:     17ʰ01ᵐ52ˢ
+}
+
+func ExampleZmanimTimes() {
+	// Jerusalem on 2024 March 20.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 35, 14, 0),
+		Lat: unit.NewAngle(' ', 31, 47, 0),
+	}
+	for _, c := range []prayer.Convention{prayer.GRA, prayer.MGA} {
+		z, err := prayer.ZmanimTimes(2024, 3, 20, p, c, prayer.HighLatNone)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("sof zman shma: %02s  plag hamincha: %02s\n",
+			sexa.FmtTime(z.SofZmanShma), sexa.FmtTime(z.PlagHamincha))
+	}
+	// Output:
+	// sof zman shma:  06ʰ44ᵐ36ˢ  plag hamincha:  14ʰ34ᵐ55ˢ
+	// sof zman shma:  06ʰ08ᵐ36ˢ  plag hamincha:  15ʰ31ᵐ55ˢ
+}
+
+func TestHighLat(t *testing.T) {
+	// London in June: the Sun does not reach 18° depression.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 0, 7, 0),
+		Lat: unit.NewAngle(' ', 51, 30, 0),
+	}
+	s, err := prayer.SalatTimes(2024, 6, 21, p, prayer.MWL,
+		prayer.Standard, prayer.HighLatNone)
+	if err != rise.ErrorCircumpolar {
+		t.Fatal("expected ErrorCircumpolar, got", err)
+	}
+	if s != (prayer.Salat{}) {
+		t.Fatal("expected zero Salat on error")
+	}
+	for _, r := range []prayer.HighLat{prayer.MiddleOfNight,
+		prayer.OneSeventh, prayer.AngleBased} {
+		s, err := prayer.SalatTimes(2024, 6, 21, p, prayer.MWL,
+			prayer.Hanafi, r)
+		if err != nil {
+			t.Fatal(r, err)
+		}
+		// Fajr before sunrise and Isha after Maghrib, within the night
+		night := (s.Sunrise - s.Maghrib).Mod1()
+		if d := (s.Sunrise - s.Fajr).Mod1(); d <= 0 || d > night/2+1 {
+			t.Fatal(r, "Fajr", d)
+		}
+		if d := (s.Isha - s.Maghrib).Mod1(); d <= 0 || d > night/2+1 {
+			t.Fatal(r, "Isha", d)
+		}
+	}
+}
+
+func TestIshaInterval(t *testing.T) {
+	// St. John's, Newfoundland, in June: Maghrib is near the end of the
+	// UT day, so Maghrib plus 90 minutes falls on the next UT day.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 52, 43, 0),
+		Lat: unit.NewAngle(' ', 47, 34, 0),
+	}
+	s, err := prayer.SalatTimes(2024, 6, 21, p, prayer.UmmAlQura,
+		prayer.Standard, prayer.MiddleOfNight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Maghrib+prayer.UmmAlQura.IshaInterval < 86400 {
+		t.Fatal("test case does not wrap, Maghrib", s.Maghrib)
+	}
+	if s.Isha < 0 || s.Isha >= 86400 {
+		t.Fatal("Isha not normalized", s.Isha)
+	}
+	if d := (s.Isha - s.Maghrib).Mod1(); math.Abs(d.Min()-90) > 1e-6 {
+		t.Fatal("Isha - Maghrib", d.Min())
+	}
+}
+
+func TestZmanimError(t *testing.T) {
+	// London in June: the Sun does not reach 16.1° depression.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 0, 7, 0),
+		Lat: unit.NewAngle(' ', 51, 30, 0),
+	}
+	z, err := prayer.ZmanimTimes(2024, 6, 21, p, prayer.GRA, prayer.HighLatNone)
+	if err != rise.ErrorCircumpolar {
+		t.Fatal("expected ErrorCircumpolar, got", err)
+	}
+	if z != (prayer.Zmanim{}) {
+		t.Fatal("expected zero Zmanim on error")
+	}
+}
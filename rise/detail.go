@@ -0,0 +1,115 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/interp"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Detail holds rise, transit and set times with the positions of the body
+// at those times.
+//
+// Times are UT in seconds of day, as returned by Times.  Azimuths are
+// measured westward from the South, as in package coord.  DayLength is the
+// time the body is above the standard altitude, from rise to set.
+//
+// For a body that does not rise and set, Err is ErrorCircumpolar.  Transit
+// and TransitAlt are still valid, and DayLength is 24 hours if the body is
+// always up or zero if it is always down.
+type Detail struct {
+	Rise, Transit, Set unit.Time
+	RiseAz, SetAz      unit.Angle
+	TransitAlt         unit.Angle
+	DayLength          unit.Time
+	Err                error
+}
+
+// TimesDetail computes rise, transit and set times as Times, and also
+// azimuths of rising and setting, altitude at transit, and day length.
+//
+// Arguments are as for Times.
+func TimesDetail(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []unit.RA, δ3 []unit.Angle) (d Detail) {
+	var err error
+	if d.Transit, err = Transit(p, ΔT, Th0, α3); err != nil {
+		d.Err = err
+		return
+	}
+	δf := make([]float64, 3)
+	for i, δ := range δ3 {
+		δf[i] = δ.Rad()
+	}
+	d3α, err := len3RA(α3)
+	if err != nil {
+		d.Err = err
+		return
+	}
+	d3δ, err := interp.NewLen3(-86400, 86400, δf)
+	if err != nil {
+		d.Err = err
+		return
+	}
+	// horizontal coordinates at UT m
+	hz := func(m unit.Time) (A, h unit.Angle) {
+		st := Th0 + m.Mul(360.985647/360)
+		ut := (m + ΔT).Sec()
+		α := unit.RAFromRad(d3α.InterpolateX(ut))
+		δ := unit.Angle(d3δ.InterpolateX(ut))
+		return coord.EqToHz(α, δ, p.Lat, p.Lon, st)
+	}
+	_, d.TransitAlt = hz(d.Transit)
+	d.Rise, _, d.Set, d.Err = Times(p, ΔT, h0, Th0, α3, δ3)
+	if d.Err != nil {
+		if d.Err == ErrorCircumpolar && d.TransitAlt > h0 {
+			d.DayLength = 86400
+		}
+		d.Rise, d.Set = 0, 0
+		return
+	}
+	d.RiseAz, _ = hz(d.Rise)
+	d.SetAz, _ = hz(d.Set)
+	d.DayLength = (d.Set - d.Rise).Mod1()
+	return
+}
+
+// SunDetail computes Detail for sunrise and sunset on a day of interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//
+// Positions of the Sun are computed with solar.ApparentEquatorial, and
+// the standard altitude is Stdh0Solar.
+func SunDetail(yr, mon, day int, pos globe.Coord) Detail {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	α := make([]unit.RA, 3)
+	δ := make([]unit.Angle, 3)
+	for i := range α {
+		α[i], δ[i] = solar.ApparentEquatorial(jd + float64(i-1))
+	}
+	return TimesDetail(pos, deltat.Interp10A(jd), Stdh0Solar,
+		sidereal.Apparent0UT(jd), α, δ)
+}
+
+// SunYear computes SunDetail for every day of Gregorian year yr.
+//
+// The result has one element per day, starting with January 1.
+func SunYear(yr int, pos globe.Coord) []Detail {
+	n := 365
+	if julian.LeapYearGregorian(yr) {
+		n++
+	}
+	ds := make([]Detail, n)
+	for i := range ds {
+		// CalendarGregorianToJD accepts day numbers past the end of
+		// January.
+		ds[i] = SunDetail(yr, 1, i+1, pos)
+	}
+	return ds
+}
@@ -0,0 +1,146 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/elliptic"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// A Body gives the apparent right ascension and declination of a celestial
+// body at UT jd, and the standard altitude h0 of the body at that time.
+type Body func(jd float64) (α unit.RA, δ unit.Angle, h0 unit.Angle)
+
+// SunBody returns a Body for the Sun, using the low precision
+// solar.ApparentEquatorial.
+//
+// Argument h0 is the standard altitude, typically Stdh0Solar or one of
+// the twilight altitudes.
+func SunBody(h0 unit.Angle) Body {
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ := solar.ApparentEquatorial(jd + deltat.Interp10A(jd).Day())
+		return α, δ, h0
+	}
+}
+
+// PlanetBody returns a Body for a planet, using elliptic.Position and
+// Stdh0Stellar.
+//
+// Arguments e and pl must be V87Planet objects for Earth and the planet.
+func PlanetBody(e, pl *pp.V87Planet) Body {
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ := elliptic.Position(pl, e, jd+deltat.Interp10A(jd).Day())
+		return α, δ, Stdh0Stellar
+	}
+}
+
+// EventType identifies the kind of an Event.
+type EventType int
+
+const (
+	EventRise EventType = iota
+	EventSet
+	EventUpperTransit
+	EventLowerTransit
+)
+
+var eventNames = [...]string{"rise", "set", "upper transit", "lower transit"}
+
+// String returns a lower case description of the event type.
+func (t EventType) String() string { return eventNames[t] }
+
+// Event is a rise, set, or transit of a body.  JD is UT.
+type Event struct {
+	Type EventType
+	JD   float64
+}
+
+// EventList is the result of Events.
+//
+// Events are in chronological order.  When there is no rise or set in the
+// interval, exactly one of AlwaysUp or AlwaysDown is true, according to
+// whether the body is above or below its standard altitude throughout.
+type EventList struct {
+	Events     []Event
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+// Events finds rises, sets, and upper and lower transits of body b for
+// observer p in the UT interval [jd0, jd1).
+//
+// Any number of events of each kind may be found.  Unlike Times, Events
+// handles days with no rise or no set of the Moon, and polar day and
+// night.
+func Events(p globe.Coord, b Body, jd0, jd1 float64) (el EventList) {
+	hz := eqHz(p, b)
+	for _, c := range hz.riseSet(jd0, jd1) {
+		t := EventSet
+		if c.up {
+			t = EventRise
+		}
+		el.Events = append(el.Events, Event{t, c.jd})
+	}
+	if len(el.Events) == 0 {
+		_, h, h0 := hz(jd0)
+		el.AlwaysUp = h > h0
+		el.AlwaysDown = !el.AlwaysUp
+	}
+	for _, c := range hz.transits(jd0, jd1) {
+		t := EventLowerTransit
+		if c.up {
+			t = EventUpperTransit
+		}
+		el.Events = append(el.Events, Event{t, c.jd})
+	}
+	sort.Slice(el.Events, func(i, j int) bool {
+		return el.Events[i].JD < el.Events[j].JD
+	})
+	return
+}
+
+// LocalEvents finds events of body b for observer p on a local calendar
+// day.
+//
+// Argument y, m, d is a Gregorian date in location loc.  Event JDs are UT
+// and can be converted to local time with julian.JDToTimeIn.
+func LocalEvents(y, m, d int, loc *time.Location, p globe.Coord, b Body) EventList {
+	return Events(p, b, julian.LocalDayToJD(y, m, d, loc),
+		julian.LocalDayToJD(y, m, d+1, loc))
+}
+
+// Crossing is a time when a body crosses an altitude.  JD is UT.
+// Rising is true if the body is ascending.
+type Crossing struct {
+	JD     float64
+	Rising bool
+}
+
+// Crossings finds all times in the UT interval [jd0, jd1) that body b
+// crosses altitude h, as seen from p.
+//
+// Altitude h is the geometric altitude of the center of the body, in the
+// same sense as a standard altitude.  The standard altitude returned by b
+// is not used.  To find times the Sun is at 6° apparent altitude, for
+// example, h should be reduced by the refraction at that altitude, about
+// 8′.  See package refraction.
+func Crossings(p globe.Coord, b Body, h unit.Angle, jd0, jd1 float64) []Crossing {
+	hz := eqHz(p, func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ, _ := b(jd)
+		return α, δ, h
+	})
+	var cs []Crossing
+	for _, c := range hz.riseSet(jd0, jd1) {
+		cs = append(cs, Crossing{c.jd, c.up})
+	}
+	return cs
+}
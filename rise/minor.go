@@ -0,0 +1,153 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"math"
+	"sort"
+
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/elliptic"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/nearparabolic"
+	"github.com/yanjunhui/meeus/parabolic"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/precess"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Orbit describes the orbit of a comet or asteroid about the Sun.
+//
+// Inc, ArgP, and Node orient the orbit and are referred to the ecliptic
+// and equinox of J2000.  AnomalyDistance gives true anomaly and distance
+// in AU from the Sun for a jde.
+//
+// Construct an Orbit with EllipticOrbit, ParabolicOrbit, or
+// NearParabolicOrbit.
+type Orbit struct {
+	Inc             unit.Angle // Inclination, i
+	ArgP            unit.Angle // Argument of perihelion, ω
+	Node            unit.Angle // Longitude of ascending node, Ω
+	AnomalyDistance func(jde float64) (ν unit.Angle, r float64)
+}
+
+// EllipticOrbit returns an Orbit for elliptic elements k.
+func EllipticOrbit(k *elliptic.Elements) Orbit {
+	return Orbit{k.Inc, k.ArgP, k.Node, k.AnomalyDistance}
+}
+
+// ParabolicOrbit returns an Orbit for parabolic elements k and the
+// orientation elements i, ω, Ω.
+func ParabolicOrbit(k *parabolic.Elements, i, ω, Ω unit.Angle) Orbit {
+	return Orbit{i, ω, Ω, k.AnomalyDistance}
+}
+
+// NearParabolicOrbit returns an Orbit for near-parabolic elements k and
+// the orientation elements i, ω, Ω.
+//
+// If the algorithm of nearparabolic fails to converge, AnomalyDistance
+// returns NaN values.
+func NearParabolicOrbit(k *nearparabolic.Elements, i, ω, Ω unit.Angle) Orbit {
+	return Orbit{i, ω, Ω, func(jde float64) (unit.Angle, float64) {
+		ν, r, err := k.AnomalyDistance(jde)
+		if err != nil {
+			return unit.Angle(math.NaN()), math.NaN()
+		}
+		return ν, r
+	}}
+}
+
+// Position returns the position of the body at jde, referred to the
+// equinox of date.
+//
+// The astrometric J2000 position of elliptic.AstrometricJ2000 is precessed
+// to the equinox of date.  Nutation and aberration are ignored as they are
+// small compared to the uncertainty of rise and set times.
+//
+// Argument e must be a V87Planet object for Earth.
+func (o Orbit) Position(jde float64, e *pp.V87Planet) (unit.RA, unit.Angle) {
+	f := elliptic.RectangularJ2000(o.Inc, o.ArgP, o.Node, o.AnomalyDistance)
+	α, δ, _ := elliptic.AstrometricJ2000(f, jde, e)
+	eq := &coord.Equatorial{RA: α, Dec: δ}
+	precess.Position(eq, eq, 2000, base.JDEToJulianYear(jde), 0, 0)
+	return eq.RA, eq.Dec
+}
+
+// Body returns a Body for the orbiting object, with standard altitude
+// Stdh0Stellar.
+//
+// Argument e must be a V87Planet object for Earth.
+func (o Orbit) Body(e *pp.V87Planet) Body {
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		// Body takes UT; Position takes dynamical time.
+		α, δ := o.Position(jd+deltat.Interp10A(jd).Day(), e)
+		return α, δ, Stdh0Stellar
+	}
+}
+
+// Times computes UT rise, transit and set times for the orbiting object on
+// a day of interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	e must be a V87Planet object for Earth.
+//
+// Result units are seconds of day and are in the range [0,86400).
+//
+// Times uses the interpolation of the function Times.  For a comet
+// moving rapidly close to the Earth, use Body with Events instead.
+func (o Orbit) Times(yr, mon, day int, pos globe.Coord, e *pp.V87Planet) (tRise, tTransit, tSet unit.Time, err error) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	α := make([]unit.RA, 3)
+	δ := make([]unit.Angle, 3)
+	// positions at 0h dynamical time, as Times requires
+	for i := range α {
+		α[i], δ[i] = o.Position(jd+float64(i-1), e)
+	}
+	return Times(pos, deltat.Interp10A(jd), Stdh0Stellar,
+		sidereal.Apparent0UT(jd), α, δ)
+}
+
+// ObservingTime returns the time within the UT interval [jd0, jd1) that
+// body b is above altitude h while the Sun is below altitude sunH.
+//
+// For example, with h of 20° and sunH of Stdh0Astronomical, the result is
+// the time the body is at least 20° high in a fully dark sky.
+//
+// The Sun position is computed with the low precision
+// solar.ApparentEquatorial.
+func ObservingTime(p globe.Coord, b Body, h, sunH unit.Angle, jd0, jd1 float64) unit.Time {
+	hb := eqHz(p, func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ, _ := b(jd)
+		return α, δ, h
+	})
+	hs := eqHz(p, SunBody(sunH))
+	// times where either condition changes
+	t := []float64{jd0}
+	for _, c := range hb.riseSet(jd0, jd1) {
+		t = append(t, c.jd)
+	}
+	for _, c := range hs.riseSet(jd0, jd1) {
+		t = append(t, c.jd)
+	}
+	t = append(t, jd1)
+	sort.Float64s(t)
+	var sum float64
+	for i := 1; i < len(t); i++ {
+		if t[i] <= t[i-1] {
+			continue
+		}
+		mid := (t[i-1] + t[i]) / 2
+		_, bh, _ := hb(mid)
+		_, sh, _ := hs(mid)
+		if bh > h && sh < sunH {
+			sum += t[i] - t[i-1]
+		}
+	}
+	return unit.TimeFromDay(sum)
+}
@@ -0,0 +1,89 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/moonposition"
+	"github.com/yanjunhui/meeus/nutation"
+	"github.com/yanjunhui/meeus/parallax"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// MoonTimes holds UT times of moonrise, transit, and moonset for a day.
+//
+// Units are seconds of day.  The Moon rises about 50 minutes later each
+// day, so on about one day a month there is no moonrise and on another
+// there is no moonset.  NoRise, NoTransit, and NoSet are true when the
+// corresponding event does not occur on the day, and the time is then zero.
+type MoonTimes struct {
+	Rise, Transit, Set       unit.Time
+	NoRise, NoTransit, NoSet bool
+}
+
+// Moon computes UT times of moonrise, transit, and moonset for a day of
+// interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//
+// The Moon moves too fast for the interpolation of Times to be adequate.
+// Moon instead samples topocentric positions of the Moon through the day
+// and iterates on each event.  Rise and set are for the upper limb on the
+// horizon, with mean refraction, using the semidiameter of the moment.
+//
+// If there are two events of a kind on the day, as can happen at high
+// latitudes, the first is returned.
+func Moon(yr, mon, day int, pos globe.Coord) (mt MoonTimes) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	hz := eqHz(pos, MoonBody(pos))
+	mt.NoRise, mt.NoSet, mt.NoTransit = true, true, true
+	for _, c := range hz.riseSet(jd, jd+1) {
+		t := unit.TimeFromDay(c.jd - jd)
+		switch {
+		case c.up && mt.NoRise:
+			mt.Rise, mt.NoRise = t, false
+		case !c.up && mt.NoSet:
+			mt.Set, mt.NoSet = t, false
+		}
+	}
+	for _, c := range hz.transits(jd, jd+1) {
+		if c.up {
+			mt.Transit, mt.NoTransit = unit.TimeFromDay(c.jd-jd), false
+			break
+		}
+	}
+	return
+}
+
+// MoonBody returns a Body for the Moon seen from pos.
+//
+// Positions are topocentric, so the Body is valid only for observer pos.
+// The standard altitude is that of the upper limb, -34′ less the
+// semidiameter of the moment.
+func MoonBody(pos globe.Coord) Body {
+	return moonBody(pos, 0)
+}
+
+// moonBody returns a Body for the Moon seen from pos at height h meters.
+func moonBody(pos globe.Coord, h float64) Body {
+	ρsφʹ, ρcφʹ := globe.Earth76.ParallaxConstants(pos.Lat, h)
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		jde := jd + deltat.Interp10A(jd).Day()
+		λ, β, Δ := moonposition.Position(jde)
+		Δψ, Δε := nutation.Nutation(jde)
+		ε := nutation.MeanObliquity(jde) + Δε
+		sε, cε := ε.Sincos()
+		α, δ := coord.EclToEq(λ+Δψ, β, sε, cε)
+		// parallax.Topocentric takes sidereal time from its last
+		// argument, so UT is passed.
+		α, δ = parallax.Topocentric(α, δ, Δ/base.AU, ρsφʹ, ρcφʹ, pos.Lon, jd)
+		s := moonposition.Parallax(Δ).Mul(.2725)
+		return α, δ, -meanRefraction - s
+	}
+}
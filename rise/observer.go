@@ -0,0 +1,109 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Dip returns the dip of the horizon for an observer at height h meters
+// above a sea level horizon.
+//
+// The dip is the angle by which the apparent sea horizon lies below the
+// mathematical horizon.  The value includes the effect of normal
+// terrestrial refraction, 1.76′ √h.
+func Dip(h float64) unit.Angle {
+	if h <= 0 {
+		return 0
+	}
+	return unit.AngleFromMin(1.76 * math.Sqrt(h))
+}
+
+// Observer is an observer on the Earth at a height above sea level, with
+// an optional horizon profile.
+//
+// Height is in meters.  It is used for the dip of the horizon and for the
+// parallax of the Moon.
+//
+// Horizon, if not nil, gives the altitude of the visible skyline as a
+// function of azimuth.  Azimuth is measured westward from the South, as
+// in package coord, and is in the range -π to π.  When Horizon is given,
+// rise and set are reckoned against it and the dip is not applied, as the
+// profile is taken to be measured from the observer's position.
+type Observer struct {
+	globe.Coord
+	Height  float64
+	Horizon func(A unit.Angle) unit.Angle
+}
+
+// Stdh0 returns standard altitude h0 adjusted for the dip of the horizon
+// at the height of the observer.
+//
+// The result may be passed to ApproxTimes or Times in place of h0.  It
+// does not account for a horizon profile.
+func (o Observer) Stdh0(h0 unit.Angle) unit.Angle {
+	return h0 - Dip(o.Height)
+}
+
+// Body returns a Body that is b with its standard altitude adjusted for
+// the dip of the horizon or for the horizon profile of the observer.
+func (o Observer) Body(b Body) Body {
+	if o.Horizon == nil {
+		return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+			α, δ, h0 := b(jd)
+			return α, δ, o.Stdh0(h0)
+		}
+	}
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ, h0 := b(jd)
+		A, _ := coord.EqToHz(α, δ, o.Lat, o.Lon, sidereal.Apparent(jd))
+		return α, δ, h0 + o.Horizon(A)
+	}
+}
+
+// MoonBody returns a Body for the Moon seen from the observer, including
+// the height of the observer in the topocentric positions.
+//
+// The Body is not adjusted for dip or horizon profile.  Use Observer.Body
+// or Observer.Events for that.
+func (o Observer) MoonBody() Body {
+	return moonBody(o.Coord, o.Height)
+}
+
+// Events finds rises, sets, and transits of body b in the UT interval
+// [jd0, jd1), as does the function Events, but with rise and set
+// reckoned against the horizon of the observer.
+func (o Observer) Events(b Body, jd0, jd1 float64) EventList {
+	return Events(o.Coord, o.Body(b), jd0, jd1)
+}
+
+// HorizonProfile returns a function suitable for Observer.Horizon that
+// interpolates linearly in a table of horizon altitudes.
+//
+// Azimuths az must be increasing, in the range -π to π, and the table
+// wraps around from the last entry to the first.  Slices az and alt must
+// be the same length.
+func HorizonProfile(az, alt []unit.Angle) func(unit.Angle) unit.Angle {
+	return func(A unit.Angle) unit.Angle {
+		n := len(az)
+		i := 0
+		for i < n && az[i] <= A {
+			i++
+		}
+		// interpolate between entries i-1 and i, wrapping around
+		a0, h0 := az[(i+n-1)%n], alt[(i+n-1)%n]
+		a1, h1 := az[i%n], alt[i%n]
+		span := (a1 - a0).Mod1()
+		if span == 0 {
+			return h0
+		}
+		f := (A - a0).Mod1().Rad() / span.Rad()
+		return h0 + (h1 - h0).Mul(f)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/iterate"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/solstice"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Period is an interval of time, from Start to End as UT JDs.
+type Period struct {
+	Start, End float64
+}
+
+// MidnightSun returns periods of Gregorian year yr when the Sun does not
+// set at latitude φ.
+func MidnightSun(yr int, φ unit.Angle) []Period {
+	return AlwaysAbove(yr, φ, Stdh0Solar)
+}
+
+// PolarNight returns periods of Gregorian year yr when the Sun does not
+// rise at latitude φ.
+func PolarNight(yr int, φ unit.Angle) []Period {
+	return AlwaysBelow(yr, φ, Stdh0Solar)
+}
+
+// ContinuousTwilight returns periods of Gregorian year yr when the Sun at
+// latitude φ does not go below altitude h, such as Stdh0Civil for "white
+// nights" or Stdh0Astronomical for periods with no astronomical night.
+//
+// Periods of midnight sun are included.
+func ContinuousTwilight(yr int, φ, h unit.Angle) []Period {
+	return AlwaysAbove(yr, φ, h)
+}
+
+// AlwaysAbove returns periods of Gregorian year yr when the Sun at
+// latitude φ remains above altitude h throughout the day.
+//
+// The condition is that the Sun is above h at lower culmination, which
+// depends only on latitude and the declination of the Sun.  Times where
+// the declination reaches the limiting value are found by bisection
+// between solstices.  Period boundaries are thus instants rather than
+// dates, and are accurate to within about a day, as the declination
+// changes through the day.  Periods continuing past the start or end of
+// the year are cut off there.
+func AlwaysAbove(yr int, φ, h unit.Angle) []Period {
+	// lower culmination altitude is |φ| - 90° + s δ, s the hemisphere.
+	s, aφ := hemisphere(φ)
+	lim := math.Pi/2 - aφ + h.Rad()
+	return periods(yr, func(δ float64) float64 { return s*δ - lim })
+}
+
+// AlwaysBelow returns periods of Gregorian year yr when the Sun at
+// latitude φ remains below altitude h throughout the day.
+//
+// The condition is that the Sun is below h at upper culmination.
+// See AlwaysAbove for notes on accuracy.
+func AlwaysBelow(yr int, φ, h unit.Angle) []Period {
+	// upper culmination altitude is 90° - |φ| + s δ.
+	s, aφ := hemisphere(φ)
+	lim := aφ - math.Pi/2 + h.Rad()
+	return periods(yr, func(δ float64) float64 { return lim - s*δ })
+}
+
+func hemisphere(φ unit.Angle) (s, aφ float64) {
+	if φ < 0 {
+		return -1, -φ.Rad()
+	}
+	return 1, φ.Rad()
+}
+
+// periods returns periods of year yr when f of the solar declination is
+// positive.
+func periods(yr int, f func(δ float64) float64) (ps []Period) {
+	g := func(jd float64) float64 {
+		_, δ := solar.ApparentEquatorial(jd + deltat.Interp10A(jd).Day())
+		return f(δ.Rad())
+	}
+	ut := func(jde float64) float64 {
+		return jde - deltat.Interp10A(jde).Day()
+	}
+	// declination is monotonic between these times
+	t := []float64{
+		julian.CalendarGregorianToJD(yr, 1, 1),
+		ut(solstice.June(yr)),
+		ut(solstice.December(yr)),
+		julian.CalendarGregorianToJD(yr+1, 1, 1),
+	}
+	var start float64
+	in := g(t[0]) > 0
+	if in {
+		start = t[0]
+	}
+	for i := 1; i < len(t); i++ {
+		if in == (g(t[i]) > 0) {
+			continue
+		}
+		x := iterate.BinaryRoot(iterate.RootFunc(g), t[i-1], t[i])
+		if in {
+			ps = append(ps, Period{start, x})
+		} else {
+			start = x
+		}
+		in = !in
+	}
+	if in {
+		ps = append(ps, Period{start, t[len(t)-1]})
+	}
+	return
+}
@@ -8,13 +8,20 @@ package rise_test
 
 import (
 	"fmt"
+	"math"
+	"testing"
 	"time"
 
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
 	"github.com/yanjunhui/meeus/deltat"
 	"github.com/yanjunhui/meeus/elliptic"
 	"github.com/yanjunhui/meeus/globe"
 	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/nearparabolic"
+	"github.com/yanjunhui/meeus/parabolic"
 	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/precess"
 	"github.com/yanjunhui/meeus/rise"
 	"github.com/yanjunhui/meeus/sexa"
 	"github.com/yanjunhui/meeus/sidereal"
@@ -183,3 +190,76 @@ func ExamplePlanet() {
 	// transit:  +0.81980  19ʰ40ᵐ30ˢ
 	// seting:   +0.12130  02ʰ54ᵐ40ˢ
 }
+
+func TestSunVSOP87(t *testing.T) {
+	// Full theory agrees with the low precision solar position to well
+	// within a minute.
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	for _, m := range []int{1, 3, 6, 9, 12} {
+		lp := rise.Sun(1988, m, 20, p)
+		vp := rise.SunVSOP87(1988, m, 20, p, e)
+		for _, d := range []unit.Time{
+			lp.Transit - vp.Transit,
+			lp.Sun.Rise - vp.Sun.Rise,
+			lp.Sun.Set - vp.Sun.Set,
+			lp.Astronomical.Rise - vp.Astronomical.Rise,
+			lp.Astronomical.Set - vp.Astronomical.Set,
+		} {
+			if math.Abs(d.Sec()) > 30 {
+				t.Errorf("month %d: difference %.0fs", m, d.Sec())
+			}
+		}
+	}
+}
+
+func TestOrbit(t *testing.T) {
+	e, err := pp.LoadPlanet(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Comet Encke, Example 33.a, p. 232.
+	k := &elliptic.Elements{
+		TimeP: julian.CalendarGregorianToJD(1990, 10, 28.54502),
+		Axis:  2.2091404,
+		Ecc:   .8502196,
+		Inc:   unit.AngleFromDeg(11.94524),
+		Node:  unit.AngleFromDeg(334.75006),
+		ArgP:  unit.AngleFromDeg(186.23352),
+	}
+	jde := julian.CalendarGregorianToJD(1990, 10, 6)
+	α, δ, _ := k.Position(jde, e)
+	eq := &coord.Equatorial{RA: α, Dec: δ}
+	precess.Position(eq, eq, 2000, base.JDEToJulianYear(jde), 0, 0)
+	oα, oδ := rise.EllipticOrbit(k).Position(jde, e)
+	if math.Abs(oα.Rad()-eq.RA.Rad()) > 1e-9 || math.Abs((oδ-eq.Dec).Rad()) > 1e-9 {
+		t.Fatal("elliptic:", oα, oδ, eq.RA, eq.Dec)
+	}
+	// Parabolic and near-parabolic orbits agree for e = 1.
+	q := .5871
+	pa := rise.ParabolicOrbit(&parabolic.Elements{TimeP: jde, PDis: q},
+		k.Inc, k.ArgP, k.Node)
+	np := rise.NearParabolicOrbit(
+		&nearparabolic.Elements{TimeP: jde, PDis: q, Ecc: 1},
+		k.Inc, k.ArgP, k.Node)
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	r1, t1, s1, err1 := pa.Times(1990, 10, 20, p, e)
+	r2, t2, s2, err2 := np.Times(1990, 10, 20, p, e)
+	if err1 != nil || err2 != nil {
+		t.Fatal(err1, err2)
+	}
+	for _, d := range []unit.Time{r1 - r2, t1 - t2, s1 - s2} {
+		if math.Abs(d.Sec()) > 1 {
+			t.Fatal("parabolic:", r1, t1, s1, "near-parabolic:", r2, t2, s2)
+		}
+	}
+}
@@ -9,14 +9,22 @@
 // The function signatures aren't very friendly though, requiring a number of
 // precomputed values.  The example worked in the text gives these values for
 // the planet Venus.  With these example values as test data, methods
-// ApproxPlanet and Planet are also given here.  Similar methods for stars,
-// the Sun, Moon, Pluto, or asteroids might also be developed using other
-// packages from this library.
+// ApproxPlanet and Planet are also given here.
+//
+// Functions Sun and SunVSOP87 give times of sunrise, sunset, and twilight.
+// Function Moon gives times of moonrise and moonset, function Star those
+// of a star from catalog coordinates, and type Orbit those of a comet or
+// asteroid from orbital elements.
+//
+// Function Events takes a different approach, scanning an interval for all
+// rises, sets, and transits of a Body.  Type Observer adds the height of
+// the observer and the local skyline.
 package rise
 
 import (
 	"errors"
 	"math"
+	"time"
 
 	"github.com/yanjunhui/meeus/deltat"
 	"github.com/yanjunhui/meeus/elliptic"
@@ -111,16 +119,12 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 	if err != nil {
 		return
 	}
-	αf := make([]float64, 3)
-	for i, α := range α3 {
-		αf[i] = α.Rad()
-	}
 	δf := make([]float64, 3)
 	for i, δ := range δ3 {
 		δf[i] = δ.Rad()
 	}
 	var d3α, d3δ *interp.Len3
-	d3α, err = interp.NewLen3(-86400, 86400, αf)
+	d3α, err = len3RA(α3)
 	if err != nil {
 		return
 	}
@@ -128,14 +132,7 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 	if err != nil {
 		return
 	}
-	// adjust tTransit
-	{
-		th0 := (Th0 + tTransit.Mul(360.985647/360)).Mod1()
-		α := d3α.InterpolateX((tTransit + ΔT).Sec())
-		// local hour angle as Time
-		H := th0 - unit.TimeFromRad(p.Lon.Rad()+α)
-		tTransit -= H
-	}
+	tTransit = adjustTransit(p, ΔT, Th0, tTransit, d3α)
 	// adjust tRise, tSet
 	sLat, cLat := p.Lat.Sincos()
 	adjustRS := func(m unit.Time) (unit.Time, error) {
@@ -158,6 +155,52 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 	return
 }
 
+// len3RA returns an interpolation table for three right ascensions at
+// one day intervals.
+//
+// Values are made continuous across 0h, as noted on p. 105.
+func len3RA(α3 []unit.RA) (*interp.Len3, error) {
+	αf := make([]float64, 3)
+	for i, α := range α3 {
+		αf[i] = α.Rad()
+		if i > 0 {
+			for αf[i]-αf[i-1] > math.Pi {
+				αf[i] -= 2 * math.Pi
+			}
+			for αf[i]-αf[i-1] < -math.Pi {
+				αf[i] += 2 * math.Pi
+			}
+		}
+	}
+	return interp.NewLen3(-86400, 86400, αf)
+}
+
+// adjustTransit applies a correction to an approximate transit time m.
+func adjustTransit(p globe.Coord, ΔT, Th0, m unit.Time, d3α *interp.Len3) unit.Time {
+	th0 := (Th0 + m.Mul(360.985647/360)).Mod1()
+	α := d3α.InterpolateX((m + ΔT).Sec())
+	// local hour angle as Time, in the range -12h to +12h
+	H := (th0 - unit.TimeFromRad(p.Lon.Rad()+α) + 43200).Mod1() - 43200
+	return m - H
+}
+
+// Transit computes the UT transit time for a celestial object on a day of
+// interest.
+//
+// Arguments are as for Times, except that h0 and δ3 are not needed.
+// Unlike Times, Transit gives a result for circumpolar objects.
+//
+// Result units are seconds of day.
+func Transit(p globe.Coord, ΔT unit.Time, Th0 unit.Time, α3 []unit.RA) (unit.Time, error) {
+	d3α, err := len3RA(α3)
+	if err != nil {
+		return 0, err
+	}
+	// approximate transit as in ApproxTimes
+	m := (unit.TimeFromRad(α3[1].Rad()+p.Lon.Rad()) - Th0).Mod1()
+	return adjustTransit(p, ΔT, Th0, m, d3α), nil
+}
+
 // ApproxPlanet computes approximate UT rise, transit and set times for
 // a planet on a day of interest.
 //
@@ -196,3 +239,49 @@ func Planet(yr, mon, day int, pos globe.Coord, e, pl *pp.V87Planet) (tRise, tTra
 	return Times(pos, deltat.Interp10A(jd), Stdh0Stellar,
 		sidereal.Apparent0UT(jd), α, δ)
 }
+
+// DayFunc computes UT rise, transit, and set times for the UT day
+// beginning at jd, in the manner of ApproxTimes, Times, or Planet.
+//
+// Result units are seconds of day.
+type DayFunc func(jd float64) (tRise, tTransit, tSet unit.Time, err error)
+
+// LocalTimes computes rise, transit and set times for a local calendar day.
+//
+//	y, m, d are the Gregorian date in location loc.
+//	f computes times for a UT day.
+//
+// A local day generally overlaps two UT days.  LocalTimes calls f for the
+// UT days overlapping the local day and returns the events that fall
+// within the local day, as time.Time values in location loc.  An event
+// that does not occur in the local day is returned as the zero time.Time.
+//
+// Err is ErrorCircumpolar if f returns ErrorCircumpolar for all UT days
+// considered.  Other errors from f are returned immediately.
+func LocalTimes(y, m, d int, loc *time.Location, f DayFunc) (tRise, tTransit, tSet time.Time, err error) {
+	jd0 := julian.LocalDayToJD(y, m, d, loc)
+	jd1 := julian.LocalDayToJD(y, m, d+1, loc)
+	found := func(t *time.Time, jd float64, tx unit.Time) {
+		if t.IsZero() {
+			if j := jd + tx.Day(); j >= jd0 && j < jd1 {
+				*t = julian.JDToTimeIn(j, loc)
+			}
+		}
+	}
+	err = ErrorCircumpolar
+	// UT days beginning before the local day may contain events of it.
+	for jd := math.Floor(jd0-.5) - .5; jd < jd1; jd++ {
+		r, tr, s, e := f(jd)
+		switch e {
+		case nil:
+			err = nil
+			found(&tRise, jd, r)
+			found(&tTransit, jd, tr)
+			found(&tSet, jd, s)
+		case ErrorCircumpolar:
+		default:
+			return tRise, tTransit, tSet, e
+		}
+	}
+	return
+}
@@ -5,10 +5,15 @@ package rise_test
 
 import (
 	"fmt"
+	"math"
+	"testing"
+	"time"
 
 	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
 	"github.com/yanjunhui/meeus/rise"
 	"github.com/yanjunhui/meeus/sexa"
+	"github.com/yanjunhui/meeus/sidereal"
 	"github.com/yanjunhui/meeus/unit"
 )
 
@@ -71,3 +76,396 @@ func ExampleTimes() {
 	// transit: +0.81980  19ʰ40ᵐ30ˢ
 	// seting:  +0.12130  02ʰ54ᵐ40ˢ
 }
+
+func ExampleLocalTimes() {
+	// Venus from Boston on 1988 March 20, local time, using the
+	// approximate times and the positions of Example 15.a for each day.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	α := unit.NewRA(2, 46, 55.51)
+	δ := unit.NewAngle(' ', 18, 26, 27.3)
+	f := func(jd float64) (tRise, tTransit, tSet unit.Time, err error) {
+		return rise.ApproxTimes(p, rise.Stdh0Stellar, sidereal.Apparent0UT(jd), α, δ)
+	}
+	est := time.FixedZone("EST", -5*3600)
+	tRise, tTransit, tSet, err := rise.LocalTimes(1988, 3, 20, est, f)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("rising: ", tRise.Format("Jan 2 15:04 MST"))
+	fmt.Println("transit:", tTransit.Format("Jan 2 15:04 MST"))
+	fmt.Println("setting:", tSet.Format("Jan 2 15:04 MST"))
+	// Output:
+	// rising:  Mar 20 07:26 EST
+	// transit: Mar 20 14:40 EST
+	// setting: Mar 20 21:50 EST
+}
+
+func ExampleSun() {
+	// Boston, the location of Example 15.a, on 1988 March 20.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	st := rise.Sun(1988, 3, 20, p)
+	fmt.Printf("astronomical: %02s\n", sexa.FmtTime(st.Astronomical.Rise))
+	fmt.Printf("nautical:     %02s\n", sexa.FmtTime(st.Nautical.Rise))
+	fmt.Printf("civil:        %02s\n", sexa.FmtTime(st.Civil.Rise))
+	fmt.Printf("sunrise:      %02s\n", sexa.FmtTime(st.Sun.Rise))
+	fmt.Printf("transit:      %02s\n", sexa.FmtTime(st.Transit))
+	fmt.Printf("sunset:       %02s\n", sexa.FmtTime(st.Sun.Set))
+	fmt.Printf("civil:        %02s\n", sexa.FmtTime(st.Civil.Set))
+	fmt.Printf("nautical:     %02s\n", sexa.FmtTime(st.Nautical.Set))
+	fmt.Printf("astronomical: %02s\n", sexa.FmtTime(st.Astronomical.Set))
+	// Output:
+	// astronomical:  09ʰ12ᵐ57ˢ
+	// nautical:      09ʰ46ᵐ25ˢ
+	// civil:         10ʰ19ᵐ13ˢ
+	// sunrise:       10ʰ47ᵐ12ˢ
+	// transit:       16ʰ51ᵐ42ˢ
+	// sunset:        22ʰ56ᵐ56ˢ
+	// civil:         23ʰ24ᵐ59ˢ
+	// nautical:      23ʰ57ᵐ52ˢ
+	// astronomical:  00ʰ30ᵐ08ˢ
+}
+
+func ExampleSun_midnightSun() {
+	// Tromsø on 2024 June 21.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 18, 57, 0),
+		Lat: unit.NewAngle(' ', 69, 39, 0),
+	}
+	st := rise.Sun(2024, 6, 21, p)
+	fmt.Printf("transit: %02s\n", sexa.FmtTime(st.Transit))
+	fmt.Println("sunrise:", st.Sun.Err)
+	fmt.Println("civil:  ", st.Civil.Err)
+	// Output:
+	// transit:  10ʰ46ᵐ07ˢ
+	// sunrise: Circumpolar
+	// civil:   Circumpolar
+}
+
+func ExampleMoon() {
+	// Boston, the location of Example 15.a, for a few days of
+	// 2024 January.  There is no moonrise on January 28 UT.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	fmtT := func(t unit.Time, none bool) string {
+		if none {
+			return "    --    "
+		}
+		return fmt.Sprintf("%02s", sexa.FmtTime(t))
+	}
+	for d := 26; d <= 30; d++ {
+		mt := rise.Moon(2024, 1, d, p)
+		fmt.Printf("Jan %d  rise %s  transit %s  set %s\n", d,
+			fmtT(mt.Rise, mt.NoRise),
+			fmtT(mt.Transit, mt.NoTransit),
+			fmtT(mt.Set, mt.NoSet))
+	}
+	// Output:
+	// Jan 26  rise  22ʰ43ᵐ37ˢ  transit  05ʰ23ᵐ51ˢ  set  12ʰ57ᵐ51ˢ
+	// Jan 27  rise  23ʰ47ᵐ35ˢ  transit  06ʰ09ᵐ13ˢ  set  13ʰ22ᵐ32ˢ
+	// Jan 28  rise     --      transit  06ʰ51ᵐ35ˢ  set  13ʰ43ᵐ29ˢ
+	// Jan 29  rise  00ʰ49ᵐ46ˢ  transit  07ʰ31ᵐ45ˢ  set  14ʰ02ᵐ10ˢ
+	// Jan 30  rise  01ʰ50ᵐ43ˢ  transit  08ʰ10ᵐ43ˢ  set  14ʰ19ᵐ48ˢ
+}
+
+func ExampleEvents() {
+	// The Sun at Tromsø on 2024 June 21 and December 21, UT days.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 18, 57, 0),
+		Lat: unit.NewAngle(' ', 69, 39, 0),
+	}
+	for _, d := range []int{6, 12} {
+		jd := julian.CalendarGregorianToJD(2024, d, 21)
+		el := rise.Events(p, rise.SunBody(rise.Stdh0Solar), jd, jd+1)
+		fmt.Println(time.Month(d), "always up:", el.AlwaysUp,
+			"always down:", el.AlwaysDown)
+		for _, e := range el.Events {
+			fmt.Printf("  %02s %s\n",
+				sexa.FmtTime(unit.TimeFromDay(e.JD-jd)), e.Type)
+		}
+	}
+	// Output:
+	// June always up: true always down: false
+	//    10ʰ46ᵐ07ˢ upper transit
+	//    22ʰ46ᵐ14ˢ lower transit
+	// December always up: false always down: true
+	//    10ʰ42ᵐ29ˢ upper transit
+	//    22ʰ42ᵐ44ˢ lower transit
+}
+
+func ExampleLocalEvents() {
+	// The Moon from Boston on 2024 January 28, local time.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	est := time.FixedZone("EST", -5*3600)
+	el := rise.LocalEvents(2024, 1, 28, est, p, rise.MoonBody(p))
+	for _, e := range el.Events {
+		fmt.Println(julian.JDToTimeIn(e.JD, est).Format("Jan 2 15:04 MST"), e.Type)
+	}
+	// Output:
+	// Jan 28 01:51 EST upper transit
+	// Jan 28 08:43 EST set
+	// Jan 28 14:11 EST lower transit
+	// Jan 28 19:49 EST rise
+}
+
+func ExampleDip() {
+	fmt.Printf("%.1f′\n", rise.Dip(100).Min())
+	// Output:
+	// 17.6′
+}
+
+func ExampleObserver() {
+	// Sunrise and sunset at Boston on 1988 March 20 from sea level, from
+	// a height of 1000 m, and with a 3° ridge to the east.
+	c := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	ridge := rise.HorizonProfile(
+		[]unit.Angle{
+			unit.AngleFromDeg(-180),
+			unit.AngleFromDeg(-120),
+			unit.AngleFromDeg(-100),
+			unit.AngleFromDeg(-80),
+			unit.AngleFromDeg(-60),
+		},
+		[]unit.Angle{0, 0, unit.AngleFromDeg(3), unit.AngleFromDeg(3), 0})
+	jd := julian.CalendarGregorianToJD(1988, 3, 20)
+	for _, o := range []rise.Observer{
+		{Coord: c},
+		{Coord: c, Height: 1000},
+		{Coord: c, Horizon: ridge},
+	} {
+		for _, e := range o.Events(rise.SunBody(rise.Stdh0Solar), jd, jd+1).Events {
+			switch e.Type {
+			case rise.EventRise:
+				fmt.Printf("rise: %02s  ", sexa.FmtTime(unit.TimeFromDay(e.JD-jd)))
+			case rise.EventSet:
+				fmt.Printf("set: %02s\n", sexa.FmtTime(unit.TimeFromDay(e.JD-jd)))
+			}
+		}
+	}
+	// Output:
+	// rise:  10ʰ47ᵐ12ˢ  set:  22ʰ56ᵐ56ˢ
+	// rise:  10ʰ42ᵐ11ˢ  set:  23ʰ01ᵐ58ˢ
+	// rise:  11ʰ03ᵐ25ˢ  set:  22ʰ56ᵐ56ˢ
+}
+
+func ExampleSunDetail() {
+	// Boston, the location of Example 15.a, on 1988 March 20.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	d := rise.SunDetail(1988, 3, 20, p)
+	// Azimuths here are measured westward from the South.  Add 180° for
+	// the more common convention of measuring eastward from the North.
+	fmt.Printf("sunrise:    %02s  azimuth %.2f°\n", sexa.FmtTime(d.Rise), d.RiseAz.Deg())
+	fmt.Printf("transit:    %02s  altitude %.2f°\n", sexa.FmtTime(d.Transit), d.TransitAlt.Deg())
+	fmt.Printf("sunset:     %02s  azimuth %.2f°\n", sexa.FmtTime(d.Set), d.SetAz.Deg())
+	fmt.Printf("day length: %02s\n", sexa.FmtTime(d.DayLength))
+	// Output:
+	// sunrise:     10ʰ47ᵐ12ˢ  azimuth -90.79°
+	// transit:     16ʰ51ᵐ42ˢ  altitude 47.79°
+	// sunset:      22ʰ56ᵐ56ˢ  azimuth 91.06°
+	// day length:  12ʰ09ᵐ44ˢ
+}
+
+func ExampleSunYear() {
+	// Longest and shortest days at Boston in 1988.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	ds := rise.SunYear(1988, p)
+	long, short := 0, 0
+	for i, d := range ds {
+		if d.DayLength > ds[long].DayLength {
+			long = i
+		}
+		if d.DayLength < ds[short].DayLength {
+			short = i
+		}
+	}
+	for _, i := range []int{long, short} {
+		m, d := julian.DayOfYearToCalendar(i+1, true)
+		fmt.Printf("%s %d: %02s\n", time.Month(m), d, sexa.FmtTime(ds[i].DayLength))
+	}
+	// Output:
+	// June 21:  15ʰ16ᵐ49ˢ
+	// December 21:  09ʰ04ᵐ41ˢ
+}
+
+func TestSunDetailPolar(t *testing.T) {
+	// Tromsø: midnight sun and polar night.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 18, 57, 0),
+		Lat: unit.NewAngle(' ', 69, 39, 0),
+	}
+	d := rise.SunDetail(2024, 6, 21, p)
+	if d.Err != rise.ErrorCircumpolar || d.DayLength != 86400 {
+		t.Fatal("June:", d.Err, d.DayLength)
+	}
+	if math.Abs(d.TransitAlt.Deg()-43.8) > .1 {
+		t.Fatal("June transit altitude:", d.TransitAlt.Deg())
+	}
+	d = rise.SunDetail(2024, 12, 21, p)
+	if d.Err != rise.ErrorCircumpolar || d.DayLength != 0 {
+		t.Fatal("December:", d.Err, d.DayLength)
+	}
+}
+
+func ExampleStar() {
+	// Sirius from Boston on 1988 March 20.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	α := unit.NewRA(6, 45, 8.917)
+	δ := unit.NewAngle('-', 16, 42, 58.02)
+	mα := unit.HourAngleFromSec(-.03847)
+	mδ := unit.AngleFromSec(-1.2053)
+	tRise, tTransit, tSet, err := rise.Star(1988, 3, 20, p, α, δ, mα, mδ)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("rising:  %02s\n", sexa.FmtTime(tRise))
+	fmt.Printf("transit: %02s\n", sexa.FmtTime(tTransit))
+	fmt.Printf("setting: %02s\n", sexa.FmtTime(tSet))
+	// Output:
+	// rising:   18ʰ35ᵐ04ˢ
+	// transit:  23ʰ34ᵐ07ˢ
+	// setting:  04ʰ37ᵐ07ˢ
+}
+
+func ExampleHeliacalRising() {
+	// Heliacal rising and setting of Sirius seen from Memphis, Egypt,
+	// in 2024, with an arcus visionis of 8°.
+	p := globe.Coord{
+		Lon: unit.NewAngle('-', 31, 15, 0),
+		Lat: unit.NewAngle(' ', 29, 51, 0),
+	}
+	α := unit.NewRA(6, 45, 8.917)
+	δ := unit.NewAngle('-', 16, 42, 58.02)
+	mα := unit.HourAngleFromSec(-.03847)
+	mδ := unit.AngleFromSec(-1.2053)
+	av := unit.AngleFromDeg(8)
+	jd, err := rise.HeliacalRising(2024, p, α, δ, mα, mδ, av)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("rising: ", julian.JDToTime(jd).Format("Jan 2 15:04 UT"))
+	jd, err = rise.HeliacalSetting(2024, p, α, δ, mα, mδ, av)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("setting:", julian.JDToTime(jd).Format("Jan 2 15:04 UT"))
+	// Output:
+	// rising:  Aug 1 02:37 UT
+	// setting: May 30 17:29 UT
+}
+
+func ExampleObservingTime() {
+	// Time Sirius is at least 20° high in a fully dark sky at Boston,
+	// on the night following 1988 March 20.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	b := rise.StarBody(unit.NewRA(6, 45, 8.917),
+		unit.NewAngle('-', 16, 42, 58.02),
+		unit.HourAngleFromSec(-.03847), unit.AngleFromSec(-1.2053))
+	jd := julian.CalendarGregorianToJD(1988, 3, 20.5)
+	t := rise.ObservingTime(p, b, unit.AngleFromDeg(20),
+		rise.Stdh0Astronomical, jd, jd+1)
+	fmt.Printf("%02s\n", sexa.FmtTime(t))
+	// Output:
+	// 01ʰ45ᵐ41ˢ
+}
+
+func ExampleCrossings() {
+	// Golden hour and blue hour at Boston on the evening of 1988 March 20,
+	// taken as the Sun descending from 6° to -4°, and from -4° to -6°.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	jd := julian.CalendarGregorianToJD(1988, 3, 20)
+	sun := rise.SunBody(rise.Stdh0Solar)
+	setting := func(h float64) unit.Time {
+		for _, c := range rise.Crossings(p, sun, unit.AngleFromDeg(h), jd, jd+1.5) {
+			if !c.Rising {
+				return unit.TimeFromDay(c.JD - jd).Mod1()
+			}
+		}
+		return 0
+	}
+	fmt.Printf("golden hour: %02s to %02s\n",
+		sexa.FmtTime(setting(6)), sexa.FmtTime(setting(-4)))
+	fmt.Printf("blue hour:   %02s to %02s\n",
+		sexa.FmtTime(setting(-4)), sexa.FmtTime(setting(-6)))
+	// Output:
+	// golden hour:  22ʰ19ᵐ53ˢ to  23ʰ14ᵐ06ˢ
+	// blue hour:    23ʰ14ᵐ06ˢ to  23ʰ24ᵐ58ˢ
+}
+
+func ExampleMidnightSun() {
+	// Tromsø in 2024.
+	φ := unit.NewAngle(' ', 69, 39, 0)
+	pr := func(ps []rise.Period) {
+		for _, p := range ps {
+			fmt.Printf("  %s to %s\n",
+				julian.JDToTime(p.Start).Format("Jan 2"),
+				julian.JDToTime(p.End).Format("Jan 2"))
+		}
+	}
+	fmt.Println("midnight sun:")
+	pr(rise.MidnightSun(2024, φ))
+	fmt.Println("polar night:")
+	pr(rise.PolarNight(2024, φ))
+	fmt.Println("no astronomical night:")
+	pr(rise.ContinuousTwilight(2024, φ, rise.Stdh0Astronomical))
+	// Output:
+	// midnight sun:
+	//   May 17 to Jul 25
+	// polar night:
+	//   Jan 1 to Jan 15
+	//   Nov 27 to Jan 1
+	// no astronomical night:
+	//   Mar 26 to Sep 16
+}
+
+func TestPolarSouth(t *testing.T) {
+	// At the Antarctic circle the midnight sun straddles the new year.
+	ps := rise.MidnightSun(2024, unit.AngleFromDeg(-75))
+	if len(ps) != 2 {
+		t.Fatal(ps)
+	}
+	jd0 := julian.CalendarGregorianToJD(2024, 1, 1)
+	jd1 := julian.CalendarGregorianToJD(2025, 1, 1)
+	if ps[0].Start != jd0 || ps[1].End != jd1 {
+		t.Fatal(ps)
+	}
+	// Times agrees on days well inside and outside the period.
+	p := globe.Coord{Lat: unit.AngleFromDeg(-75)}
+	if st := rise.Sun(2024, 1, 1, p); st.Sun.Err != rise.ErrorCircumpolar {
+		t.Fatal("Jan 1:", st.Sun.Err)
+	}
+	if st := rise.Sun(2024, 3, 20, p); st.Sun.Err != nil {
+		t.Fatal("Mar 20:", st.Sun.Err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/iterate"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Functions here find events by sampling the altitude and hour angle of a
+// body through an interval and refining sign changes by binary search.
+// Unlike Times, they make no assumption that the body moves slowly or
+// that events occur once per day.
+
+// scanStep is the sampling interval in days.  It must be short enough
+// that the body cannot rise and set, or transit twice, within one step.
+const scanStep = 1. / 48
+
+// hzFunc returns local hour angle H and altitude h of a body at UT jd,
+// with the standard altitude h0 at that instant.
+type hzFunc func(jd float64) (H, h, h0 unit.Angle)
+
+// eqHz returns an hzFunc for body b seen from p.
+func eqHz(p globe.Coord, b Body) hzFunc {
+	sφ, cφ := p.Lat.Sincos()
+	return func(jd float64) (H, h, h0 unit.Angle) {
+		α, δ, h0 := b(jd)
+		H = sidereal.Apparent(jd).Angle() - p.Lon - unit.Angle(α.Rad())
+		sδ, cδ := δ.Sincos()
+		h = unit.Angle(math.Asin(sφ*sδ + cφ*cδ*H.Cos()))
+		return H, h, h0
+	}
+}
+
+// crossing is a sign change of a function found by scan.
+type crossing struct {
+	jd float64
+	up bool // function increasing
+}
+
+// scan finds sign changes of f in the interval [jd0, jd1).
+func scan(f func(jd float64) float64, jd0, jd1 float64) (c []crossing) {
+	n := int(math.Ceil((jd1 - jd0) / scanStep))
+	step := (jd1 - jd0) / float64(n)
+	t0, f0 := jd0, f(jd0)
+	for i := 1; i <= n; i++ {
+		t1 := jd0 + float64(i)*step
+		f1 := f(t1)
+		if math.Signbit(f0) != math.Signbit(f1) {
+			t := iterate.BinaryRoot(iterate.RootFunc(f), t0, t1)
+			if t < jd1 {
+				c = append(c, crossing{t, f1 > f0})
+			}
+		}
+		t0, f0 = t1, f1
+	}
+	return
+}
+
+// riseSet returns crossings of the standard altitude.
+func (hz hzFunc) riseSet(jd0, jd1 float64) []crossing {
+	return scan(func(jd float64) float64 {
+		_, h, h0 := hz(jd)
+		return (h - h0).Rad()
+	}, jd0, jd1)
+}
+
+// transits returns meridian crossings.  Upper transits are those where
+// crossing.up is true.
+func (hz hzFunc) transits(jd0, jd1 float64) (c []crossing) {
+	f := func(jd float64) float64 {
+		H, _, _ := hz(jd)
+		return H.Sin()
+	}
+	for _, x := range scan(f, jd0, jd1) {
+		H, _, _ := hz(x.jd)
+		// sin H increases through upper transit and decreases
+		// through lower transit.
+		x.up = H.Cos() > 0
+		c = append(c, x)
+	}
+	return
+}
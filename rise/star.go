@@ -0,0 +1,128 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"errors"
+
+	"github.com/yanjunhui/meeus/apparent"
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// starPosition returns the apparent position at UT jd of a star with
+// J2000 catalog position α, δ and annual proper motion mα, mδ.
+func starPosition(α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle, jd float64) (unit.RA, unit.Angle) {
+	eq := &coord.Equatorial{RA: α, Dec: δ}
+	jde := jd + deltat.Interp10A(jd).Day()
+	apparent.Position(eq, eq, 2000, base.JDEToJulianYear(jde), mα, mδ)
+	return eq.RA, eq.Dec
+}
+
+// Star computes UT rise, transit and set times for a star on a day of
+// interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	α, δ are the catalog position of the star, for equinox and epoch J2000.
+//	mα, mδ are the annual proper motion of the star.
+//
+// Proper motion, precession, nutation, and aberration are applied with
+// apparent.Position.
+//
+// Result units are seconds of day and are in the range [0,86400).
+func Star(yr, mon, day int, pos globe.Coord, α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle) (tRise, tTransit, tSet unit.Time, err error) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	return starTimes(jd, pos, α, δ, mα, mδ)
+}
+
+func starTimes(jd float64, pos globe.Coord, α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle) (tRise, tTransit, tSet unit.Time, err error) {
+	α3 := make([]unit.RA, 3)
+	δ3 := make([]unit.Angle, 3)
+	for i := range α3 {
+		α3[i], δ3[i] = starPosition(α, δ, mα, mδ, jd+float64(i-1))
+	}
+	return Times(pos, deltat.Interp10A(jd), Stdh0Stellar,
+		sidereal.Apparent0UT(jd), α3, δ3)
+}
+
+// StarBody returns a Body for a star.  Arguments are as for Star.
+func StarBody(α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle) Body {
+	return func(jd float64) (unit.RA, unit.Angle, unit.Angle) {
+		α, δ := starPosition(α, δ, mα, mδ, jd)
+		return α, δ, Stdh0Stellar
+	}
+}
+
+// ErrorNoHeliacal is returned by HeliacalRising and HeliacalSetting when
+// the star has no heliacal rising or setting in the year.
+var ErrorNoHeliacal = errors.New("No heliacal event")
+
+// sunAltitude returns the altitude of the Sun at UT jd.
+func sunAltitude(pos globe.Coord, jd float64) unit.Angle {
+	α, δ := solar.ApparentEquatorial(jd + deltat.Interp10A(jd).Day())
+	_, h := coord.EqToHz(α, δ, pos.Lat, pos.Lon, sidereal.Apparent(jd))
+	return h
+}
+
+// HeliacalRising finds the heliacal rising of a star in Gregorian year yr,
+// the first day the star is visible rising in the morning twilight.
+//
+// Arguments pos, α, δ, mα, mδ are as for Star.  Argument av is the arcus
+// visionis, the depression of the Sun below the horizon needed for the star
+// to be seen at its rising.  It depends on the brightness of the star and
+// conditions of observation.  Values of 7° to 11° are typical for bright
+// stars.
+//
+// The result is the UT JD of the rising of the star on that morning.
+func HeliacalRising(yr int, pos globe.Coord, α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle, av unit.Angle) (float64, error) {
+	return heliacal(yr, pos, α, δ, mα, mδ, av, true)
+}
+
+// HeliacalSetting finds the heliacal setting of a star in Gregorian year
+// yr, the last day the star is visible setting in the evening twilight.
+//
+// Arguments are as for HeliacalRising.  The result is the UT JD of the
+// setting of the star on that evening.
+func HeliacalSetting(yr int, pos globe.Coord, α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle, av unit.Angle) (float64, error) {
+	return heliacal(yr, pos, α, δ, mα, mδ, av, false)
+}
+
+func heliacal(yr int, pos globe.Coord, α unit.RA, δ unit.Angle, mα unit.HourAngle, mδ unit.Angle, av unit.Angle, rising bool) (float64, error) {
+	// visible returns true if the star is seen rising (or setting) on the
+	// UT day beginning jd, and the JD of the event.
+	visible := func(jd float64) (bool, float64) {
+		tRise, _, tSet, err := starTimes(jd, pos, α, δ, mα, mδ)
+		if err != nil {
+			return false, 0
+		}
+		t := jd + tSet.Day()
+		if rising {
+			t = jd + tRise.Day()
+		}
+		return sunAltitude(pos, t) <= -av, t
+	}
+	jd0 := julian.CalendarGregorianToJD(yr, 1, 1)
+	jd1 := julian.CalendarGregorianToJD(yr+1, 1, 1)
+	prev, prevT := visible(jd0 - 1)
+	for jd := jd0; jd < jd1; jd++ {
+		v, t := visible(jd)
+		switch {
+		case rising && v && !prev:
+			// first morning of visibility
+			return t, nil
+		case !rising && prev && !v && prevT >= jd0:
+			// day before was the last evening of visibility
+			return prevT, nil
+		}
+		prev, prevT = v, t
+	}
+	return 0, ErrorNoHeliacal
+}
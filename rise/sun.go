@@ -0,0 +1,94 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Altitudes of the center of the Sun defining the beginning and end of
+// twilight.
+var (
+	Stdh0Civil        = unit.AngleFromDeg(-6)
+	Stdh0Nautical     = unit.AngleFromDeg(-12)
+	Stdh0Astronomical = unit.AngleFromDeg(-18)
+)
+
+// RiseSet holds UT rise and set times for a single standard altitude.
+//
+// For twilight, Rise is the beginning of morning twilight and Set is the
+// end of evening twilight.  Err is ErrorCircumpolar when the Sun does not
+// cross the altitude on the day.  Rise and Set are then zero.
+type RiseSet struct {
+	Rise, Set unit.Time
+	Err       error
+}
+
+// SunTimes holds UT times of sunrise, solar transit, sunset, and twilight
+// for a day.
+//
+// Units are seconds of day.
+type SunTimes struct {
+	Transit      unit.Time
+	Sun          RiseSet // sunrise and sunset, at Stdh0Solar
+	Civil        RiseSet // civil twilight, at Stdh0Civil
+	Nautical     RiseSet // nautical twilight, at Stdh0Nautical
+	Astronomical RiseSet // astronomical twilight, at Stdh0Astronomical
+}
+
+// Sun computes UT times of sunrise, solar transit, sunset, and twilight
+// for a day of interest.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//
+// Positions of the Sun are computed with solar.ApparentEquatorial, which
+// is of low precision but adequate for rise and set times.
+func Sun(yr, mon, day int, pos globe.Coord) SunTimes {
+	return sunTimes(yr, mon, day, pos, solar.ApparentEquatorial)
+}
+
+// SunVSOP87 computes UT times of sunrise, solar transit, sunset, and
+// twilight for a day of interest, using the full VSOP87 theory.
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	e must be a V87Planet object for Earth, obtained with the
+//	planetposition package.
+func SunVSOP87(yr, mon, day int, pos globe.Coord, e *pp.V87Planet) SunTimes {
+	return sunTimes(yr, mon, day, pos, func(jde float64) (unit.RA, unit.Angle) {
+		α, δ, _ := solar.ApparentEquatorialVSOP87(e, jde)
+		return α, δ
+	})
+}
+
+func sunTimes(yr, mon, day int, pos globe.Coord, f func(float64) (unit.RA, unit.Angle)) (st SunTimes) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	α := make([]unit.RA, 3)
+	δ := make([]unit.Angle, 3)
+	α[0], δ[0] = f(jd - 1)
+	α[1], δ[1] = f(jd)
+	α[2], δ[2] = f(jd + 1)
+	ΔT := deltat.Interp10A(jd)
+	Th0 := sidereal.Apparent0UT(jd)
+	st.Transit, _ = Transit(pos, ΔT, Th0, α)
+	rs := func(h0 unit.Angle) (r RiseSet) {
+		r.Rise, _, r.Set, r.Err = Times(pos, ΔT, h0, Th0, α, δ)
+		if r.Err != nil {
+			r.Rise, r.Set = 0, 0
+		}
+		return
+	}
+	st.Sun = rs(Stdh0Solar)
+	st.Civil = rs(Stdh0Civil)
+	st.Nautical = rs(Stdh0Nautical)
+	st.Astronomical = rs(Stdh0Astronomical)
+	return
+}
@@ -0,0 +1,124 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Supermoon: New and Full Moons near perigee and apogee.
+//
+// This package does not correspond to a chapter of the book.  It combines
+// the phases of package moonphase with the apsides of package apsis and
+// the distance of package moonposition.
+//
+// A "supermoon" is a New or Full Moon that occurs with the Moon near
+// perigee, and a "micromoon" one that occurs near apogee.  The terms have
+// no single definition, so the classification is configurable with a
+// Definition.  Two common ones are provided: Nolle's rule, which is
+// relative to the perigee and apogee of the current orbit, and a simple
+// threshold on distance.
+package supermoon
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/apsis"
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/moonphase"
+	"github.com/yanjunhui/meeus/moonposition"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Class is the classification of a New or Full Moon.
+type Class int
+
+const (
+	Ordinary Class = iota
+	Supermoon
+	Micromoon
+)
+
+var classNames = [...]string{"ordinary", "supermoon", "micromoon"}
+
+// String returns a lower case name of the class.
+func (c Class) String() string { return classNames[c] }
+
+// Syzygy is a New or Full Moon with the distance of the Moon and the
+// nearest apsides.
+//
+// Times are jde.  Distances are between centers of Earth and Moon, in km.
+type Syzygy struct {
+	Phase           moonphase.Phase // moonphase.NewMoon or moonphase.FullMoon
+	JDE             float64
+	Distance        float64
+	Perigee         float64 // nearest perigee
+	PerigeeDistance float64
+	Apogee          float64 // nearest apogee
+	ApogeeDistance  float64
+	Class           Class
+}
+
+// A Definition classifies a Syzygy.
+type Definition func(s *Syzygy) Class
+
+// Nolle returns a Definition following Richard Nolle, who coined the term.
+//
+// A supermoon is a New or Full Moon with distance within pct percent of
+// the nearest perigee, relative to the range from perigee to apogee.  His
+// original rule is Nolle(90), with the Moon at or beyond 90% of the way
+// from apogee to perigee.  A micromoon is defined symmetrically.
+func Nolle(pct float64) Definition {
+	f := 1 - pct/100
+	return func(s *Syzygy) Class {
+		r := s.ApogeeDistance - s.PerigeeDistance
+		switch {
+		case s.Distance <= s.PerigeeDistance+f*r:
+			return Supermoon
+		case s.Distance >= s.ApogeeDistance-f*r:
+			return Micromoon
+		}
+		return Ordinary
+	}
+}
+
+// Threshold returns a Definition by fixed distances in km.
+//
+// A supermoon is closer than super, a micromoon farther than micro.
+// For example Threshold(360000, 405000).
+func Threshold(super, micro float64) Definition {
+	return func(s *Syzygy) Class {
+		switch {
+		case s.Distance < super:
+			return Supermoon
+		case s.Distance > micro:
+			return Micromoon
+		}
+		return Ordinary
+	}
+}
+
+// distance returns distance in km for equatorial horizontal parallax π.
+func distance(π unit.Angle) float64 {
+	// inverse of moonposition.Parallax
+	return 6378.14 / math.Sin(π.Rad())
+}
+
+// Find returns the New and Full Moons in the interval [jde0, jde1),
+// classified by definition def.
+func Find(jde0, jde1 float64, def Definition) (ss []Syzygy) {
+	for _, e := range moonphase.Phases(jde0, jde1) {
+		if e.Phase != moonphase.NewMoon && e.Phase != moonphase.FullMoon {
+			continue
+		}
+		_, _, Δ := moonposition.Position(e.JDE)
+		y := base.JDEToJulianYear(e.JDE)
+		s := Syzygy{
+			Phase:           e.Phase,
+			JDE:             e.JDE,
+			Distance:        Δ,
+			Perigee:         apsis.Perigee(y),
+			PerigeeDistance: distance(apsis.PerigeeParallax(y)),
+			Apogee:          apsis.Apogee(y),
+			ApogeeDistance:  distance(apsis.ApogeeParallax(y)),
+		}
+		s.Class = def(&s)
+		ss = append(ss, s)
+	}
+	return
+}
@@ -0,0 +1,55 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package supermoon_test
+
+import (
+	"fmt"
+
+	"github.com/yanjunhui/meeus/julian"
+	"github.com/yanjunhui/meeus/supermoon"
+)
+
+func ExampleFind() {
+	// Supermoons and micromoons of 2024 by Nolle's rule.
+	jde0 := julian.CalendarGregorianToJD(2024, 1, 1)
+	jde1 := julian.CalendarGregorianToJD(2025, 1, 1)
+	for _, s := range supermoon.Find(jde0, jde1, supermoon.Nolle(90)) {
+		if s.Class == supermoon.Ordinary {
+			continue
+		}
+		y, m, d := julian.JDToCalendar(s.JDE)
+		fmt.Printf("%d-%02d-%02d %-9s %.0f km  %s\n",
+			y, m, int(d), s.Phase, s.Distance, s.Class)
+	}
+	// Output:
+	// 2024-01-11 New Moon  365204 km  supermoon
+	// 2024-02-09 New Moon  358745 km  supermoon
+	// 2024-02-24 Full Moon 405917 km  micromoon
+	// 2024-03-10 New Moon  356900 km  supermoon
+	// 2024-03-25 Full Moon 405394 km  micromoon
+	// 2024-04-08 New Moon  359810 km  supermoon
+	// 2024-05-08 New Moon  366739 km  supermoon
+	// 2024-08-19 Full Moon 361970 km  supermoon
+	// 2024-09-03 New Moon  403896 km  micromoon
+	// 2024-09-18 Full Moon 357486 km  supermoon
+	// 2024-10-02 New Moon  406516 km  micromoon
+	// 2024-10-17 Full Moon 357364 km  supermoon
+	// 2024-11-01 New Moon  403832 km  micromoon
+	// 2024-11-15 Full Moon 361867 km  supermoon
+}
+
+func ExampleThreshold() {
+	jde0 := julian.CalendarGregorianToJD(2024, 1, 1)
+	jde1 := julian.CalendarGregorianToJD(2025, 1, 1)
+	n := 0
+	for _, s := range supermoon.Find(jde0, jde1,
+		supermoon.Threshold(360000, 405000)) {
+		if s.Class == supermoon.Supermoon {
+			n++
+		}
+	}
+	fmt.Println(n, "supermoons closer than 360000 km")
+	// Output:
+	// 5 supermoons closer than 360000 km
+}
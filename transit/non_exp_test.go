@@ -0,0 +1,72 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package transit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// linear returns a state for a planet crossing the Sun in a straight line,
+// eastward at 4′ per hour, passing y north of the center of the Sun at
+// time tc.  The Sun has semidiameter 16′, the planet 29″.
+func linear(y unit.Angle, tc float64) state {
+	return func(jde float64) (d, sSun, sPl, pa unit.Angle) {
+		x := unit.AngleFromMin(4 * 24 * (jde - tc))
+		d = unit.Angle(math.Hypot(x.Rad(), y.Rad()))
+		pa = unit.Angle(math.Atan2(x.Rad(), y.Rad())).Mod1()
+		return d, unit.AngleFromMin(16), unit.AngleFromSec(29), pa
+	}
+}
+
+func TestCircumstances(t *testing.T) {
+	const jc = 2456000.
+	tc := jc + .1
+	c, max, minSep, ok := circumstances(linear(unit.AngleFromMin(10), tc), jc)
+	if !ok {
+		t.Fatal("no transit")
+	}
+	if math.Abs(max.JDE-tc)*1440 > .01 {
+		t.Fatal("greatest transit", max.JDE)
+	}
+	if math.Abs(minSep.Min()-10) > 1e-6 {
+		t.Fatal("minimum separation", minSep.Min())
+	}
+	// contacts where the separation is 16′ ± 29″
+	for i, h := range []float64{-3.27586, -2.96613, 2.96613, 3.27586} {
+		if dt := (c[i].JDE - tc) * 24; math.Abs(dt-h) > 1e-4 {
+			t.Fatal("contact", i+1, dt, "hours from greatest transit")
+		}
+		x := 4 * h
+		pa := math.Atan2(x, 10) * 180 / math.Pi
+		if pa < 0 {
+			pa += 360
+		}
+		if math.Abs(c[i].PA.Deg()-pa) > 1e-3 {
+			t.Fatal("contact", i+1, "PA", c[i].PA.Deg(), pa)
+		}
+	}
+	// grazing transit with no internal contacts
+	c, _, _, ok = circumstances(linear(unit.AngleFromMin(16.2), tc), jc)
+	if !ok {
+		t.Fatal("no grazing transit")
+	}
+	if c[0].JDE == 0 || c[1].JDE != 0 || c[2].JDE != 0 || c[3].JDE == 0 {
+		t.Fatal("grazing transit contacts", c)
+	}
+	// no transit
+	if _, _, _, ok = circumstances(linear(unit.AngleFromMin(16.6), tc), jc); ok {
+		t.Fatal("transit found")
+	}
+}
+
+func TestLocalZero(t *testing.T) {
+	var tr Transit
+	if _, ok := tr.Local(globe.Coord{}, 0); ok {
+		t.Fatal("local circumstances of zero Transit")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+//go:build !nopp
+// +build !nopp
+
+package transit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/julian"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/transit"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+func load(t *testing.T, ibody int) *pp.V87Planet {
+	p, err := pp.LoadPlanet(ibody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// checkContacts compares UT contact times to h, m, s of published times,
+// with days given relative to day d0.
+func checkContacts(t *testing.T, tr transit.Transit, y, m, d0 int, want [5][4]float64) {
+	jd0 := julian.CalendarGregorianToJD(y, m, float64(d0))
+	got := []float64{
+		tr.Contacts[0].JD, tr.Contacts[1].JD, tr.Max.JD,
+		tr.Contacts[2].JD, tr.Contacts[3].JD,
+	}
+	for i, w := range want {
+		jd := jd0 + w[0] + (w[1]+(w[2]+w[3]/60)/60)/24
+		if math.Abs(got[i]-jd)*1440 > 1.5 {
+			t.Errorf("contact %d: got %.5f, want %.5f", i, got[i], jd)
+		}
+	}
+}
+
+func TestVenus(t *testing.T) {
+	e := load(t, pp.Earth)
+	v := load(t, pp.Venus)
+	tr, ok := transit.Venus(e, v, 2012.4)
+	if !ok {
+		t.Fatal("no transit of Venus in 2012")
+	}
+	// 2012 June 5-6
+	checkContacts(t, tr, 2012, 6, 5, [5][4]float64{
+		{0, 22, 9, 38},
+		{0, 22, 27, 34},
+		{1, 1, 29, 36},
+		{1, 4, 31, 39},
+		{1, 4, 49, 35},
+	})
+	if s := tr.MinSep.Sec(); math.Abs(s-554.4) > 2 {
+		t.Error("minimum separation", s)
+	}
+	// Honolulu saw the whole transit.
+	l, ok := tr.Local(globe.Coord{
+		Lat: unit.NewAngle(' ', 21, 18, 0),
+		Lon: unit.NewAngle(' ', 157, 52, 0),
+	}, 0)
+	if !ok {
+		t.Fatal("no local transit")
+	}
+	for i, c := range l.Contacts {
+		if math.Abs(c.JD-tr.Contacts[i].JD)*1440 > 10 {
+			t.Error("local contact", i, "too far from geocentric")
+		}
+		if c.SunAlt <= 0 {
+			t.Error("local contact", i, "Sun below horizon")
+		}
+	}
+	if ts := transit.VenusTransits(e, v, 2000, 2100); len(ts) != 2 {
+		t.Error("transits of Venus 2000-2100:", len(ts))
+	}
+}
+
+func TestMercury(t *testing.T) {
+	e := load(t, pp.Earth)
+	me := load(t, pp.Mercury)
+	tr, ok := transit.Mercury(e, me, 2019.86)
+	if !ok {
+		t.Fatal("no transit of Mercury in November 2019")
+	}
+	checkContacts(t, tr, 2019, 11, 11, [5][4]float64{
+		{0, 12, 35, 27},
+		{0, 12, 37, 8},
+		{0, 15, 19, 48},
+		{0, 18, 2, 33},
+		{0, 18, 4, 4},
+	})
+	// 2003 May 7, 2006 November 8, 2016 May 9, 2019 November 11
+	if ts := transit.MercuryTransits(e, me, 2000, 2030); len(ts) != 4 {
+		t.Error("transits of Mercury 2000-2030:", len(ts))
+	}
+}
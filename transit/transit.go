@@ -0,0 +1,248 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Transit: Transits of Mercury and Venus across the Sun.
+//
+// This package does not correspond to a chapter of the book.  It starts
+// from the inferior conjunctions of package planetary and uses VSOP87
+// positions of the Sun and planet with the semidiameters of package
+// semidiameter to find whether the planet crosses the solar disk, and if
+// so the times of contact.
+//
+// Contacts I and IV are external contacts of the disks of the planet and
+// Sun, contacts II and III internal contacts.
+package transit
+
+import (
+	"math"
+
+	"github.com/yanjunhui/meeus/angle"
+	"github.com/yanjunhui/meeus/base"
+	"github.com/yanjunhui/meeus/coord"
+	"github.com/yanjunhui/meeus/deltat"
+	"github.com/yanjunhui/meeus/elliptic"
+	"github.com/yanjunhui/meeus/globe"
+	"github.com/yanjunhui/meeus/iterate"
+	"github.com/yanjunhui/meeus/parallax"
+	"github.com/yanjunhui/meeus/planetary"
+	pp "github.com/yanjunhui/meeus/planetposition"
+	"github.com/yanjunhui/meeus/semidiameter"
+	"github.com/yanjunhui/meeus/sidereal"
+	"github.com/yanjunhui/meeus/solar"
+	"github.com/yanjunhui/meeus/unit"
+)
+
+// Contact holds the time of a contact or of greatest transit.
+type Contact struct {
+	JDE float64 // dynamical time
+	JD  float64 // universal time
+	// position angle of the center of the planet from the center of the
+	// Sun, measured from north through east
+	PA unit.Angle
+}
+
+// Transit holds geocentric circumstances of a transit.
+type Transit struct {
+	// Contacts I, II, III, and IV.  II and III are zero for a grazing
+	// transit where the disk of the planet is never entirely on the Sun.
+	Contacts [4]Contact
+	Max      Contact    // greatest transit
+	MinSep   unit.Angle // minimum separation of the centers of planet and Sun
+	e, pl    *pp.V87Planet
+	s0       unit.Angle // semidiameter of the planet at 1 AU
+}
+
+// LocalContact holds local circumstances of a contact.
+type LocalContact struct {
+	Contact
+	SunAlt unit.Angle // altitude of the Sun, without refraction
+	SunAz  unit.Angle // azimuth of the Sun, measured westward from the South
+}
+
+// LocalTransit holds circumstances of a transit for an observer.
+type LocalTransit struct {
+	Contacts [4]LocalContact // as for Transit
+	Max      LocalContact
+	MinSep   unit.Angle
+}
+
+// state is a function returning the separation of planet and Sun, the
+// semidiameters of the Sun and planet, and the position angle of the
+// planet.
+type state func(jde float64) (d, sSun, sPl, pa unit.Angle)
+
+// synodic periods in days
+const (
+	mercurySynodic = 115.88
+	venusSynodic   = 583.92
+)
+
+// search is the interval in days either side of greatest transit searched
+// for contacts.
+const search = .5
+
+// Mercury returns the transit of Mercury at the inferior conjunction
+// nearest decimal year y.
+//
+// e must be the VSOP87 Earth, me the VSOP87 Mercury.  ok is false if
+// there is no transit at the conjunction.
+func Mercury(e, me *pp.V87Planet, y float64) (t Transit, ok bool) {
+	return find(e, me, semidiameter.Mercury, planetary.MercuryInfConj(y))
+}
+
+// Venus returns the transit of Venus at the inferior conjunction nearest
+// decimal year y.
+//
+// e must be the VSOP87 Earth, v the VSOP87 Venus.  ok is false if there
+// is no transit at the conjunction.
+func Venus(e, v *pp.V87Planet, y float64) (t Transit, ok bool) {
+	return find(e, v, semidiameter.VenusCloud, planetary.VenusInfConj(y))
+}
+
+// MercuryTransits returns transits of Mercury at inferior conjunctions
+// between decimal years y0 and y1.
+func MercuryTransits(e, me *pp.V87Planet, y0, y1 float64) []Transit {
+	return transits(e, me, semidiameter.Mercury, planetary.MercuryInfConj,
+		mercurySynodic, y0, y1)
+}
+
+// VenusTransits returns transits of Venus at inferior conjunctions
+// between decimal years y0 and y1.
+func VenusTransits(e, v *pp.V87Planet, y0, y1 float64) []Transit {
+	return transits(e, v, semidiameter.VenusCloud, planetary.VenusInfConj,
+		venusSynodic, y0, y1)
+}
+
+// year returns the decimal year used by package planetary for jde.
+func year(jde float64) float64 {
+	return (jde - 1721060) / 365.2425
+}
+
+func transits(e, pl *pp.V87Planet, s0 unit.Angle, conj func(float64) float64, synodic, y0, y1 float64) (ts []Transit) {
+	jc := conj(y0)
+	if year(jc) < y0 {
+		jc = conj(y0 + synodic/365.2425)
+	}
+	for ; year(jc) < y1; jc = conj(year(jc) + synodic/365.2425) {
+		if t, ok := find(e, pl, s0, jc); ok {
+			ts = append(ts, t)
+		}
+	}
+	return
+}
+
+// geocentric returns the state function for a geocentric observer.
+func geocentric(e, pl *pp.V87Planet, s0 unit.Angle) state {
+	return func(jde float64) (d, sSun, sPl, pa unit.Angle) {
+		αs, δs, R := solar.ApparentEquatorialVSOP87(e, jde)
+		α, δ, Δ := elliptic.PositionDistance(pl, e, jde)
+		return sep(αs, δs, α, δ, R, Δ, s0)
+	}
+}
+
+func sep(αs unit.RA, δs unit.Angle, α unit.RA, δ unit.Angle, R, Δ float64, s0 unit.Angle) (d, sSun, sPl, pa unit.Angle) {
+	d = angle.Sep(unit.Angle(αs), δs, unit.Angle(α), δ)
+	// base.Limb computes the position angle of one point from another.
+	pa = base.Limb(αs, δs, α, δ)
+	return d, semidiameter.Semidiameter(semidiameter.Sun, R),
+		semidiameter.Semidiameter(s0, Δ), pa
+}
+
+// circumstances finds the contacts and greatest transit for state f near
+// time jc.
+//
+// ok is false if there is no transit.
+func circumstances(f state, jc float64) (c [4]Contact, max Contact, minSep unit.Angle, ok bool) {
+	d := func(jde float64) float64 {
+		d, _, _, _ := f(jde)
+		return d.Rad()
+	}
+	// coarse scan for the minimum separation, then refine
+	tm, dm := jc, math.Inf(1)
+	for t := jc - 1; t <= jc+1; t += 1. / 24 {
+		if x := d(t); x < dm {
+			tm, dm = t, x
+		}
+	}
+	tm = iterate.GoldenMin(d, tm-1./24, tm+1./24, 1e-7)
+	minSep, sSun, sPl, _ := f(tm)
+	if minSep >= sSun+sPl {
+		return
+	}
+	contact := func(jde float64) Contact {
+		_, _, _, pa := f(jde)
+		return Contact{JDE: jde, JD: jde - deltat.Interp10A(jde).Day(), PA: pa}
+	}
+	outer := func(jde float64) float64 {
+		d, sSun, sPl, _ := f(jde)
+		return (d - sSun - sPl).Rad()
+	}
+	inner := func(jde float64) float64 {
+		d, sSun, sPl, _ := f(jde)
+		return (d - sSun + sPl).Rad()
+	}
+	max = contact(tm)
+	c[0] = contact(iterate.BinaryRoot(outer, tm-search, tm))
+	c[3] = contact(iterate.BinaryRoot(outer, tm, tm+search))
+	if minSep < sSun-sPl {
+		c[1] = contact(iterate.BinaryRoot(inner, tm-search, tm))
+		c[2] = contact(iterate.BinaryRoot(inner, tm, tm+search))
+	}
+	return c, max, minSep, true
+}
+
+func find(e, pl *pp.V87Planet, s0 unit.Angle, jc float64) (t Transit, ok bool) {
+	t.Contacts, t.Max, t.MinSep, ok = circumstances(geocentric(e, pl, s0), jc)
+	t.e, t.pl, t.s0 = e, pl, s0
+	return
+}
+
+// Local computes circumstances of the transit for an observer at p, with
+// height h in meters above the ellipsoid.
+//
+// Parallax shifts contact times by up to several minutes from the
+// geocentric times.  ok is false if the observer sees no transit, which
+// can happen only for grazing transits.  Circumstances are computed
+// regardless of whether the Sun is above the horizon; check SunAlt.
+//
+// The Transit must be one returned by Mercury, Venus, MercuryTransits, or
+// VenusTransits, which hold the VSOP87 planets for the computation.  ok is
+// false for any other Transit, such as the zero value.
+func (t *Transit) Local(p globe.Coord, h float64) (l LocalTransit, ok bool) {
+	if t.e == nil || t.pl == nil {
+		return
+	}
+	ρs, ρc := globe.Earth76.ParallaxConstants(p.Lat, h)
+	topo := func(jde float64) (αs unit.RA, δs unit.Angle, α unit.RA, δ unit.Angle, R, Δ float64, jd float64) {
+		jd = jde - deltat.Interp10A(jde).Day()
+		αs, δs, R = solar.ApparentEquatorialVSOP87(t.e, jde)
+		α, δ, Δ = elliptic.PositionDistance(t.pl, t.e, jde)
+		// parallax.Topocentric uses its time argument only for sidereal
+		// time, so UT is passed.
+		αs, δs = parallax.Topocentric(αs, δs, R, ρs, ρc, p.Lon, jd)
+		α, δ = parallax.Topocentric(α, δ, Δ, ρs, ρc, p.Lon, jd)
+		return
+	}
+	f := func(jde float64) (d, sSun, sPl, pa unit.Angle) {
+		αs, δs, α, δ, R, Δ, _ := topo(jde)
+		return sep(αs, δs, α, δ, R, Δ, t.s0)
+	}
+	c, max, minSep, ok := circumstances(f, t.Max.JDE)
+	if !ok {
+		return
+	}
+	local := func(k Contact) LocalContact {
+		if k.JDE == 0 {
+			return LocalContact{}
+		}
+		αs, δs, _, _, _, _, jd := topo(k.JDE)
+		A, h := coord.EqToHz(αs, δs, p.Lat, p.Lon, sidereal.Apparent(jd))
+		return LocalContact{k, h, A}
+	}
+	for i := range c {
+		l.Contacts[i] = local(c[i])
+	}
+	l.Max = local(max)
+	l.MinSep = minSep
+	return l, true
+}